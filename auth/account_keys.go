@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/wif"
+)
+
+// AccountKeys holds the owner, active, posting and memo keys of an account.
+type AccountKeys struct {
+	Owner   *wif.PrivateKey
+	Active  *wif.PrivateKey
+	Posting *wif.PrivateKey
+	Memo    *wif.PrivateKey
+}
+
+// GenerateAccountKeys creates a key set with an independent random key for
+// each role.
+func GenerateAccountKeys() (*AccountKeys, error) {
+	keys := make([]*wif.PrivateKey, 4)
+	for i := range keys {
+		key, err := wif.GeneratePrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+
+	return &AccountKeys{
+		Owner:   keys[0],
+		Active:  keys[1],
+		Posting: keys[2],
+		Memo:    keys[3],
+	}, nil
+}
+
+// AccountKeysFromPassword derives the key set of an account from its name and
+// password, the same way GetPrivateKeys does.
+func AccountKeysFromPassword(name, password string) (*AccountKeys, error) {
+	keys, err := GetPrivateKeys(name, password, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AccountKeys{}
+	for role, dst := range map[string]**wif.PrivateKey{
+		"owner":   &result.Owner,
+		"active":  &result.Active,
+		"posting": &result.Posting,
+		"memo":    &result.Memo,
+	} {
+		privKey := &wif.PrivateKey{}
+		if err := privKey.FromWif(keys[role]); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode WIF for role %s", role)
+		}
+		*dst = privKey
+	}
+	return result, nil
+}
+
+// PrivateKeys returns the keys as a map of role -> WIF, plus role+"Pubkey" ->
+// public key, in the format returned by GetPrivateKeys.
+func (keys *AccountKeys) PrivateKeys() map[string]string {
+	result := make(map[string]string, 8)
+	for role, key := range keys.roles() {
+		result[role] = key.ToWif()
+		result[role+"Pubkey"] = key.ToPubKeyStr()
+	}
+	return result
+}
+
+// PublicKeys returns the public keys as a map of role -> public key.
+func (keys *AccountKeys) PublicKeys() map[string]string {
+	result := make(map[string]string, 4)
+	for role, key := range keys.roles() {
+		result[role] = key.ToPubKeyStr()
+	}
+	return result
+}
+
+// OwnerAuthority returns a single-key authority for the owner key.
+func (keys *AccountKeys) OwnerAuthority() *protocol.Authority {
+	return NewKeyAuthority(keys.Owner.ToPubKeyStr())
+}
+
+// ActiveAuthority returns a single-key authority for the active key.
+func (keys *AccountKeys) ActiveAuthority() *protocol.Authority {
+	return NewKeyAuthority(keys.Active.ToPubKeyStr())
+}
+
+// PostingAuthority returns a single-key authority for the posting key.
+func (keys *AccountKeys) PostingAuthority() *protocol.Authority {
+	return NewKeyAuthority(keys.Posting.ToPubKeyStr())
+}
+
+// MemoKey returns the public memo key.
+func (keys *AccountKeys) MemoKey() string {
+	return keys.Memo.ToPubKeyStr()
+}
+
+// AccountCreateOperation builds an account_create operation for newAccount
+// using this key set. The fee is an asset string such as "3.000 STEEM".
+func (keys *AccountKeys) AccountCreateOperation(creator, newAccount, fee, jsonMetadata string) *protocol.AccountCreateOperation {
+	return &protocol.AccountCreateOperation{
+		Fee:            fee,
+		Creator:        creator,
+		NewAccountName: newAccount,
+		Owner:          keys.OwnerAuthority(),
+		Active:         keys.ActiveAuthority(),
+		Posting:        keys.PostingAuthority(),
+		MemoKey:        keys.MemoKey(),
+		JsonMetadata:   jsonMetadata,
+	}
+}
+
+// CreateClaimedAccountOperation builds a create_claimed_account operation for
+// newAccount using this key set.
+func (keys *AccountKeys) CreateClaimedAccountOperation(creator, newAccount, jsonMetadata string) *protocol.CreateClaimedAccountOperation {
+	return &protocol.CreateClaimedAccountOperation{
+		Creator:        creator,
+		NewAccountName: newAccount,
+		Owner:          keys.OwnerAuthority(),
+		Active:         keys.ActiveAuthority(),
+		Posting:        keys.PostingAuthority(),
+		MemoKey:        keys.MemoKey(),
+		JsonMetadata:   jsonMetadata,
+		Extensions:     []any{},
+	}
+}
+
+func (keys *AccountKeys) roles() map[string]*wif.PrivateKey {
+	return map[string]*wif.PrivateKey{
+		"owner":   keys.Owner,
+		"active":  keys.Active,
+		"posting": keys.Posting,
+		"memo":    keys.Memo,
+	}
+}
+
+// NewKeyAuthority returns an authority satisfied by a single public key.
+func NewKeyAuthority(pubKey string) *protocol.Authority {
+	return &protocol.Authority{
+		AccountAuths:    protocol.StringInt64Map{},
+		KeyAuths:        protocol.StringInt64Map{pubKey: 1},
+		WeightThreshold: 1,
+	}
+}
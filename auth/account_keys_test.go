@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/steemit/steemutil/encoder"
+)
+
+func TestGenerateAccountKeys(t *testing.T) {
+	keys, err := GenerateAccountKeys()
+	if err != nil {
+		t.Fatalf("GenerateAccountKeys failed: %v", err)
+	}
+
+	pubKeys := keys.PublicKeys()
+	seen := make(map[string]bool, len(pubKeys))
+	for role, pubKey := range pubKeys {
+		if !IsPubkey(pubKey) {
+			t.Errorf("invalid public key for role %s: %s", role, pubKey)
+		}
+		if seen[pubKey] {
+			t.Errorf("duplicate public key for role %s", role)
+		}
+		seen[pubKey] = true
+	}
+	if len(seen) != 4 {
+		t.Errorf("expected 4 keys, got %d", len(seen))
+	}
+
+	privKeys := keys.PrivateKeys()
+	for _, role := range []string{"owner", "active", "posting", "memo"} {
+		if !WifIsValid(privKeys[role], privKeys[role+"Pubkey"]) {
+			t.Errorf("WIF does not match public key for role %s", role)
+		}
+	}
+}
+
+func TestAccountKeysFromPassword(t *testing.T) {
+	keys, err := AccountKeysFromPassword("testuser", "testpassword")
+	if err != nil {
+		t.Fatalf("AccountKeysFromPassword failed: %v", err)
+	}
+
+	expected, err := GenerateKeys("testuser", "testpassword", []string{"owner", "active", "posting", "memo"})
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	for role, pubKey := range keys.PublicKeys() {
+		if pubKey != expected[role] {
+			t.Errorf("expected %v, got %v", expected[role], pubKey)
+		}
+	}
+}
+
+func TestAccountKeys_AccountCreateOperation(t *testing.T) {
+	keys, err := GenerateAccountKeys()
+	if err != nil {
+		t.Fatalf("GenerateAccountKeys failed: %v", err)
+	}
+
+	op := keys.AccountCreateOperation("alice", "bob", "3.000 STEEM", "{}")
+	if op.Owner.WeightThreshold != 1 || op.Owner.KeyAuths[keys.Owner.ToPubKeyStr()] != 1 {
+		t.Errorf("unexpected owner authority: %+v", op.Owner)
+	}
+	if op.MemoKey != keys.Memo.ToPubKeyStr() {
+		t.Errorf("expected %v, got %v", keys.Memo.ToPubKeyStr(), op.MemoKey)
+	}
+
+	var b bytes.Buffer
+	if err := encoder.NewEncoder(&b).Encode(op); err != nil {
+		t.Errorf("failed to encode account_create: %v", err)
+	}
+
+	claimed := keys.CreateClaimedAccountOperation("alice", "bob", "")
+	b.Reset()
+	if err := encoder.NewEncoder(&b).Encode(claimed); err != nil {
+		t.Errorf("failed to encode create_claimed_account: %v", err)
+	}
+}
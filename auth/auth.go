@@ -115,7 +115,8 @@ func WifToPublic(privWif string) (string, error) {
 	return privKey.ToPubKeyStr(), nil
 }
 
-// IsPubkey checks if the given string is a valid public key format.
+// IsPubkey checks if the given string is a valid public key with the
+// consts.ADDRESS_PREFIX prefix.
 func IsPubkey(pubkey string) bool {
 	pubKey := &wif.PublicKey{}
 	err := pubKey.FromStr(pubkey)
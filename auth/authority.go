@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/jsonrpc2"
+	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/wif"
+)
+
+// Authority levels of an account.
+const (
+	RoleOwner   = "owner"
+	RoleActive  = "active"
+	RolePosting = "posting"
+)
+
+// ErrAccountNotFound is returned by an AuthorityLookup for unknown accounts.
+var ErrAccountNotFound = errors.New("account not found")
+
+// AuthorityLookup returns the authority of an account for a role
+// (RoleOwner, RoleActive or RolePosting).
+type AuthorityLookup interface {
+	Authority(account, role string) (*protocol.Authority, error)
+}
+
+// AuthorityLookupFunc adapts a function to an AuthorityLookup.
+type AuthorityLookupFunc func(account, role string) (*protocol.Authority, error)
+
+// Authority calls f(account, role).
+func (f AuthorityLookupFunc) Authority(account, role string) (*protocol.Authority, error) {
+	return f(account, role)
+}
+
+// APIAuthorityLookup fetches authorities with condenser_api.get_accounts.
+type APIAuthorityLookup struct {
+	URL string
+}
+
+// NewAPIAuthorityLookup creates a lookup querying the API node at url.
+func NewAPIAuthorityLookup(url string) *APIAuthorityLookup {
+	return &APIAuthorityLookup{URL: url}
+}
+
+// accountAuthorities holds the authority fields of a get_accounts result.
+type accountAuthorities struct {
+	Name    string              `json:"name"`
+	Owner   *protocol.Authority `json:"owner"`
+	Active  *protocol.Authority `json:"active"`
+	Posting *protocol.Authority `json:"posting"`
+}
+
+// Authority implements AuthorityLookup.
+func (l *APIAuthorityLookup) Authority(account, role string) (*protocol.Authority, error) {
+	client := jsonrpc2.NewClient(l.URL)
+	if err := client.BuildSendData("condenser_api.get_accounts", []any{[]string{account}}); err != nil {
+		return nil, errors.Wrap(err, "failed to build get_accounts request")
+	}
+	res, err := client.Send()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call get_accounts")
+	}
+	if res.Error != nil {
+		return nil, errors.Errorf("get_accounts failed: %v", res.Error)
+	}
+
+	raw, err := json.Marshal(res.Result)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read get_accounts result")
+	}
+	var accounts []accountAuthorities
+	if err := json.Unmarshal(raw, &accounts); err != nil {
+		return nil, errors.Wrap(err, "failed to decode get_accounts result")
+	}
+	if len(accounts) == 0 || accounts[0].Name != account {
+		return nil, errors.Wrap(ErrAccountNotFound, account)
+	}
+
+	var auth *protocol.Authority
+	switch role {
+	case RoleOwner:
+		auth = accounts[0].Owner
+	case RoleActive:
+		auth = accounts[0].Active
+	case RolePosting:
+		auth = accounts[0].Posting
+	default:
+		return nil, errors.Errorf("unknown role: %s", role)
+	}
+	if auth == nil {
+		return nil, errors.Errorf("account %s has no %s authority", account, role)
+	}
+	return auth, nil
+}
+
+// AuthorityWeight sums the weights of the authority's keys found in keys and
+// reports whether they reach the weight threshold. Account auths are not
+// resolved and do not add weight.
+func AuthorityWeight(auth *protocol.Authority, keys []*wif.PublicKey) (uint32, bool, error) {
+	if auth == nil {
+		return 0, false, errors.New("authority is nil")
+	}
+
+	signed := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		signed[string(key.ToByte())] = true
+	}
+
+	var weight uint32
+	for keyStr, keyWeight := range auth.KeyAuths {
+		pubKey := &wif.PublicKey{}
+		if err := pubKey.FromStrAnyPrefix(keyStr); err != nil {
+			return 0, false, errors.Wrapf(err, "invalid key in authority: %s", keyStr)
+		}
+		if signed[string(pubKey.ToByte())] {
+			weight += uint32(keyWeight)
+		}
+	}
+	return weight, weight >= auth.WeightThreshold, nil
+}
+
+// CachedAuthorityLookup caches the authorities returned by another lookup
+// for a fixed time. Failed lookups are not cached.
+type CachedAuthorityLookup struct {
+	lookup   AuthorityLookup
+	ttl      time.Duration
+	capacity int
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cachedAuthority
+}
+
+type cachedAuthority struct {
+	auth      *protocol.Authority
+	expiresAt time.Time
+}
+
+// NewCachedAuthorityLookup caches up to capacity authorities of lookup for
+// ttl. Authority changes on chain are picked up once the entry expires.
+func NewCachedAuthorityLookup(lookup AuthorityLookup, ttl time.Duration, capacity int) *CachedAuthorityLookup {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &CachedAuthorityLookup{
+		lookup:   lookup,
+		ttl:      ttl,
+		capacity: capacity,
+		now:      time.Now,
+		entries:  make(map[string]cachedAuthority),
+	}
+}
+
+// Authority implements AuthorityLookup.
+func (c *CachedAuthorityLookup) Authority(account, role string) (*protocol.Authority, error) {
+	key := account + "/" + role
+	now := c.now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.auth, nil
+	}
+
+	auth, err := c.lookup.Authority(account, role)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= c.capacity {
+		for k, e := range c.entries {
+			if !now.Before(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		// Still full: drop an arbitrary entry.
+		for k := range c.entries {
+			if len(c.entries) < c.capacity {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cachedAuthority{auth: auth, expiresAt: now.Add(c.ttl)}
+	return auth, nil
+}
+
+// Invalidate drops the cached authorities of an account, e.g. after it
+// broadcast an account_update.
+func (c *CachedAuthorityLookup) Invalidate(account string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, role := range []string{RoleOwner, RoleActive, RolePosting} {
+		delete(c.entries, account+"/"+role)
+	}
+}
@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/protocol/api"
+	"github.com/steemit/steemutil/wif"
+)
+
+func TestAPIAuthorityLookup(t *testing.T) {
+	const url = "https://api.steemit.com"
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", url,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0","id":1,"result":[{"name":"alice",`+
+				`"owner":{"weight_threshold":1,"account_auths":[],"key_auths":[["STM5drwFJSU3ia3EjGWaWFCWkCxugSMrTyzAqT2PDifqJJfqv1cgD",1]]},`+
+				`"active":{"weight_threshold":1,"account_auths":[],"key_auths":[["STM5drwFJSU3ia3EjGWaWFCWkCxugSMrTyzAqT2PDifqJJfqv1cgD",1]]},`+
+				`"posting":{"weight_threshold":1,"account_auths":[["bob",1]],"key_auths":[["STM8UnUGrV8YLhhRBWKkkw1DZ8UvvUqqVSjA3aaTmacK5VQtWJmAS",1]]}}]}`), nil
+		},
+	)
+
+	lookup := NewAPIAuthorityLookup(url)
+	auth, err := lookup.Authority("alice", RolePosting)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if auth.WeightThreshold != 1 || auth.KeyAuths["STM8UnUGrV8YLhhRBWKkkw1DZ8UvvUqqVSjA3aaTmacK5VQtWJmAS"] != 1 || auth.AccountAuths["bob"] != 1 {
+		t.Errorf("unexpected posting authority: %+v", auth)
+	}
+
+	if _, err := lookup.Authority("carol", RolePosting); errors.Cause(err) != ErrAccountNotFound {
+		t.Errorf("expected %v, got %v", ErrAccountNotFound, err)
+	}
+	if _, err := lookup.Authority("alice", "memo"); err == nil {
+		t.Error("expected an error for an unknown role")
+	}
+}
+
+func TestAPIAuthorityLookupError(t *testing.T) {
+	const url = "https://api.steemit.com"
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", url,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewJsonResponse(200, &api.RpcResultData{JsonRpc: "2.0", Error: map[string]any{"message": "boom"}})
+		},
+	)
+
+	if _, err := NewAPIAuthorityLookup(url).Authority("alice", RolePosting); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestAuthorityWeight(t *testing.T) {
+	keys := make([]*wif.PrivateKey, 3)
+	for i := range keys {
+		key, err := wif.GeneratePrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = key
+	}
+	auth := &protocol.Authority{
+		WeightThreshold: 2,
+		AccountAuths:    protocol.StringInt64Map{},
+		KeyAuths: protocol.StringInt64Map{
+			keys[0].ToPubKeyStr(): 1,
+			keys[1].ToPubKeyStr(): 1,
+		},
+	}
+
+	weight, ok, err := AuthorityWeight(auth, []*wif.PublicKey{keys[0].ToPublicKey(), keys[2].ToPublicKey()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weight != 1 || ok {
+		t.Errorf("expected weight 1 unsatisfied, got %d %v", weight, ok)
+	}
+
+	weight, ok, err = AuthorityWeight(auth, []*wif.PublicKey{keys[0].ToPublicKey(), keys[1].ToPublicKey()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weight != 2 || !ok {
+		t.Errorf("expected weight 2 satisfied, got %d %v", weight, ok)
+	}
+}
+
+func TestCachedAuthorityLookup(t *testing.T) {
+	calls := 0
+	lookup := NewCachedAuthorityLookup(AuthorityLookupFunc(func(account, role string) (*protocol.Authority, error) {
+		calls++
+		if account == "carol" {
+			return nil, ErrAccountNotFound
+		}
+		return NewKeyAuthority("STM8UnUGrV8YLhhRBWKkkw1DZ8UvvUqqVSjA3aaTmacK5VQtWJmAS"), nil
+	}), time.Minute, 2)
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	lookup.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, err := lookup.Authority("alice", RolePosting); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+
+	// Failed lookups are not cached.
+	lookup.Authority("carol", RolePosting)
+	lookup.Authority("carol", RolePosting)
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	lookup.Authority("alice", RolePosting)
+	if calls != 4 {
+		t.Errorf("expected 4 calls, got %d", calls)
+	}
+
+	lookup.Invalidate("alice")
+	lookup.Authority("alice", RolePosting)
+	if calls != 5 {
+		t.Errorf("expected 5 calls, got %d", calls)
+	}
+
+	// The capacity bounds the cache.
+	lookup.Authority("bob", RolePosting)
+	lookup.Authority("dave", RolePosting)
+	if len(lookup.entries) > 2 {
+		t.Errorf("expected at most 2 entries, got %d", len(lookup.entries))
+	}
+}
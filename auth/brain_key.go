@@ -1,15 +1,22 @@
 package auth
 
 import (
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"math/big"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/steemit/steemutil/wif"
 )
 
 // GenerateBrainKey generates a private key from account name, password, and role.
-// This implements the brain key generation algorithm used by Steem.
+// This implements the password based key derivation used by Steem wallets.
+// Graphene brain keys are handled by BrainKeyToPrivateKey.
 func GenerateBrainKey(name, password, role string) ([]byte, error) {
 	// Create seed: name + role + password
 	seed := name + role + password
@@ -41,3 +48,123 @@ func ToWif(name, password, role string) (string, error) {
 
 	return privKey.ToWif(), nil
 }
+
+// BrainKeyWordCount is the number of dictionary words in a suggested brain key.
+const BrainKeyWordCount = 16
+
+// MinBrainKeyDictionarySize is the smallest dictionary
+// SuggestBrainKeyFromDictionary accepts.
+const MinBrainKeyDictionarySize = 2048
+
+// BrainKeyDictionarySize is the number of words in the standard Graphene
+// dictionary used by cli_wallet and steem-js.
+const BrainKeyDictionarySize = 49744
+
+// brainKeyDictionaryData is the standard Graphene dictionary, the word_list
+// of steemd's utilities/words.cpp, with one word per line.
+//
+//go:embed brain_key_dictionary.txt
+var brainKeyDictionaryData string
+
+var (
+	brainKeyDictionaryOnce sync.Once
+	brainKeyDictionary     []string
+	brainKeyDictionaryErr  error
+)
+
+// BrainKeyDictionary returns the embedded standard Graphene dictionary. It
+// fails if the embedded word list does not have BrainKeyDictionarySize words.
+func BrainKeyDictionary() ([]string, error) {
+	brainKeyDictionaryOnce.Do(func() {
+		words := ParseBrainKeyDictionary(brainKeyDictionaryData)
+		if len(words) != BrainKeyDictionarySize {
+			brainKeyDictionaryErr = errors.Errorf("embedded brain key dictionary has %d words, expected %d",
+				len(words), BrainKeyDictionarySize)
+			return
+		}
+		brainKeyDictionary = words
+	})
+	return brainKeyDictionary, brainKeyDictionaryErr
+}
+
+// BrainKeyInfo mirrors brain_key_info returned by cli_wallet's suggest_brain_key.
+type BrainKeyInfo struct {
+	BrainPrivKey string `json:"brain_priv_key"`
+	WifPrivKey   string `json:"wif_priv_key"`
+	PubKey       string `json:"pub_key"`
+}
+
+// ParseBrainKeyDictionary splits a word list separated by commas or
+// whitespace, such as the contents of steem-js's dictionary_en.js string or
+// graphene's word list, into words.
+func ParseBrainKeyDictionary(data string) []string {
+	return strings.FieldsFunc(data, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\v' || r == '\f'
+	})
+}
+
+// SuggestBrainKey suggests a brain key from the standard Graphene dictionary,
+// like cli_wallet's suggest_brain_key.
+func SuggestBrainKey() (*BrainKeyInfo, error) {
+	dictionary, err := BrainKeyDictionary()
+	if err != nil {
+		return nil, err
+	}
+	return SuggestBrainKeyFromDictionary(dictionary)
+}
+
+// SuggestBrainKeyFromDictionary picks BrainKeyWordCount random words from the
+// dictionary and returns the normalized brain key together with its first
+// derived key.
+func SuggestBrainKeyFromDictionary(dictionary []string) (*BrainKeyInfo, error) {
+	if len(dictionary) < MinBrainKeyDictionarySize {
+		return nil, errors.Errorf("brain key dictionary has %d words, at least %d required",
+			len(dictionary), MinBrainKeyDictionarySize)
+	}
+
+	size := big.NewInt(int64(len(dictionary)))
+	words := make([]string, BrainKeyWordCount)
+	for i := range words {
+		n, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read random data")
+		}
+		words[i] = dictionary[n.Int64()]
+	}
+
+	brainKey := NormalizeBrainKey(strings.Join(words, " "))
+	privKey, err := BrainKeyToPrivateKey(brainKey, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BrainKeyInfo{
+		BrainPrivKey: brainKey,
+		WifPrivKey:   privKey.ToWif(),
+		PubKey:       privKey.ToPubKeyStr(),
+	}, nil
+}
+
+// NormalizeBrainKey upper-cases the brain key and collapses whitespace into
+// single spaces, like normalize_brain_key in cli_wallet.
+func NormalizeBrainKey(brainKey string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(brainKey), " "))
+}
+
+// BrainKeyToPrivateKey derives the private key with the given sequence number
+// from a Graphene brain key: sha256(sha512(brainKey + " " + sequence)).
+func BrainKeyToPrivateKey(brainKey string, sequence int) (*wif.PrivateKey, error) {
+	if sequence < 0 {
+		return nil, errors.New("sequence number must not be negative")
+	}
+
+	seed := NormalizeBrainKey(brainKey) + " " + strconv.Itoa(sequence)
+	h := sha512.Sum512([]byte(seed))
+	key := sha256.Sum256(h[:])
+
+	privKey := &wif.PrivateKey{}
+	if err := privKey.FromByte(key[:]); err != nil {
+		return nil, errors.Wrap(err, "failed to create private key from brain key")
+	}
+	return privKey, nil
+}
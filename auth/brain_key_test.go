@@ -34,11 +34,11 @@ func TestGenerateBrainKey(t *testing.T) {
 func TestGenerateBrainKeyNormalization(t *testing.T) {
 	// Test that whitespace normalization works
 	name1 := "testuser"
-	password1 := "test  password"  // Multiple spaces
+	password1 := "test  password" // Multiple spaces
 	role1 := "posting"
 
 	name2 := "testuser"
-	password2 := "test password"   // Single space
+	password2 := "test password" // Single space
 	role2 := "posting"
 
 	brainKey1, err1 := GenerateBrainKey(name1, password1, role1)
@@ -106,3 +106,89 @@ func TestBrainKeyAlgorithm(t *testing.T) {
 	}
 }
 
+func TestNormalizeBrainKey(t *testing.T) {
+	got := NormalizeBrainKey("  abaft\tabbacy \n Abbess  abbey ")
+	expected := "ABAFT ABBACY ABBESS ABBEY"
+	if got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestBrainKeyToPrivateKey(t *testing.T) {
+	tests := []struct {
+		brainKey string
+		sequence int
+		expected string
+	}{
+		{"ABAFT ABBACY ABBESS ABBEY", 0, "5JRRRzmURRVk4xzTnWvX12pmDJVeSFkp4F7g8y9kWQXovxEkdnj"},
+		{"ABAFT ABBACY ABBESS ABBEY", 1, "5JzM8TXuRtfTpAWkFNM7D5CgmMgFvHBpg7fFEJDnsj1Vy8BJuSL"},
+		{" abaft  abbacy abbess\nabbey", 0, "5JRRRzmURRVk4xzTnWvX12pmDJVeSFkp4F7g8y9kWQXovxEkdnj"},
+	}
+
+	for _, tt := range tests {
+		privKey, err := BrainKeyToPrivateKey(tt.brainKey, tt.sequence)
+		if err != nil {
+			t.Fatalf("BrainKeyToPrivateKey failed: %v", err)
+		}
+		if privKey.ToWif() != tt.expected {
+			t.Errorf("expected %v, got %v", tt.expected, privKey.ToWif())
+		}
+	}
+
+	if _, err := BrainKeyToPrivateKey("ABAFT", -1); err == nil {
+		t.Error("expected error for negative sequence")
+	}
+}
+
+func TestSuggestBrainKeyFromDictionary(t *testing.T) {
+	words := make([]string, MinBrainKeyDictionarySize)
+	for i := range words {
+		words[i] = "word" + strings.Repeat("x", i%7) + string(rune('a'+i%26))
+	}
+	dictionary := ParseBrainKeyDictionary(strings.Join(words, ","))
+	if len(dictionary) != len(words) {
+		t.Fatalf("expected %d words, got %d", len(words), len(dictionary))
+	}
+
+	info, err := SuggestBrainKeyFromDictionary(dictionary)
+	if err != nil {
+		t.Fatalf("SuggestBrainKeyFromDictionary failed: %v", err)
+	}
+	if n := len(strings.Fields(info.BrainPrivKey)); n != BrainKeyWordCount {
+		t.Errorf("expected %d words, got %d", BrainKeyWordCount, n)
+	}
+	if info.BrainPrivKey != NormalizeBrainKey(info.BrainPrivKey) {
+		t.Errorf("brain key is not normalized: %q", info.BrainPrivKey)
+	}
+	if !WifIsValid(info.WifPrivKey, info.PubKey) {
+		t.Error("WIF does not match public key")
+	}
+
+	privKey, err := BrainKeyToPrivateKey(info.BrainPrivKey, 0)
+	if err != nil {
+		t.Fatalf("BrainKeyToPrivateKey failed: %v", err)
+	}
+	if privKey.ToWif() != info.WifPrivKey {
+		t.Errorf("expected %v, got %v", info.WifPrivKey, privKey.ToWif())
+	}
+
+	if _, err := SuggestBrainKeyFromDictionary(dictionary[:10]); err == nil {
+		t.Error("expected error for small dictionary")
+	}
+}
+
+func TestSuggestBrainKey(t *testing.T) {
+	if _, err := BrainKeyDictionary(); err != nil {
+		t.Skipf("standard dictionary not embedded: %v", err)
+	}
+	info, err := SuggestBrainKey()
+	if err != nil {
+		t.Fatalf("SuggestBrainKey failed: %v", err)
+	}
+	if n := len(strings.Fields(info.BrainPrivKey)); n != BrainKeyWordCount {
+		t.Errorf("expected %d words, got %d", BrainKeyWordCount, n)
+	}
+	if !WifIsValid(info.WifPrivKey, info.PubKey) {
+		t.Error("WIF does not match public key")
+	}
+}
@@ -1,156 +1,184 @@
 package auth
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
 	"crypto/sha256"
-	"io"
+	"crypto/sha512"
+	"encoding/binary"
 
 	"github.com/btcsuite/btcd/btcutil/base58"
 	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/consts"
+	"github.com/steemit/steemutil/signer"
 	"github.com/steemit/steemutil/wif"
 )
 
+// FC_REFLECT( steem::wallet::encrypted_memo,
+//             (from)
+//             (to)
+//             (nonce)
+//             (check)
+//             (encrypted) )
+
 // EncryptedMemo represents an encrypted memo structure.
 type EncryptedMemo struct {
 	From      string `json:"from"`
 	To        string `json:"to"`
-	Nonce     []byte `json:"nonce"`
-	Check     []byte `json:"check"`
+	Nonce     uint64 `json:"nonce"`
+	Check     uint32 `json:"check"`
 	Encrypted []byte `json:"encrypted"`
 }
 
 // Encode encrypts a memo if it starts with '#', otherwise returns it as-is.
-// privateKey can be a WIF string or a PrivateKey object.
+// privateKey can be a WIF string, a PrivateKey object or a
+// signer.SharedSecretSigner.
 // publicKey can be a public key string or a PublicKey object.
+// The result is compatible with steem-js and condenser.
 func Encode(privateKey interface{}, publicKey interface{}, memo string) (string, error) {
 	if memo == "" {
 		return "", errors.New("memo is required")
 	}
 
 	// If memo doesn't start with '#', return as-is
-	if len(memo) == 0 || memo[0] != '#' {
+	if memo[0] != '#' {
 		return memo, nil
 	}
 
-	// Remove '#' prefix
-	memo = memo[1:]
-
-	// Convert private key to PrivateKey object
-	privKey, err := toPrivateKey(privateKey)
+	nonce, err := uniqueNonce()
 	if err != nil {
-		return "", errors.Wrap(err, "failed to convert private key")
+		return "", err
 	}
+	return encodeMemo(privateKey, publicKey, memo, nonce)
+}
 
-	// Convert public key to PublicKey object
-	pubKey, err := toPublicKey(publicKey)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to convert public key")
+// Decode decrypts a memo if it starts with '#', otherwise returns it as-is.
+// The private key may belong to either the sender or the recipient.
+func Decode(privateKey interface{}, memo string) (string, error) {
+	if memo == "" {
+		return "", errors.New("memo is required")
 	}
 
-	// Get sender's public key
-	senderPubKey := &wif.PublicKey{}
-	if err := senderPubKey.FromStr(privKey.ToPubKeyStr()); err != nil {
-		return "", errors.Wrap(err, "failed to get sender public key")
+	// If memo doesn't start with '#', return as-is
+	if memo[0] != '#' {
+		return memo, nil
 	}
 
-	// Determine recipient public key
-	recipientPubKey := pubKey
-	if senderPubKey.ToStr() == pubKey.ToStr() {
-		// If sender and recipient are the same, we need the other key
-		// This is a simplified version - in practice, you'd need the actual recipient key
-		recipientPubKey = pubKey
+	memoSigner, err := toMemoSigner(privateKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to convert private key")
 	}
 
-	// Encrypt the memo
-	encrypted, nonce, checksum, err := encryptMemo(privKey, recipientPubKey, []byte(memo))
+	encMemo, err := ParseEncryptedMemo(memo)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to encrypt memo")
+		return "", err
 	}
 
-	// Create encrypted memo structure
-	encMemo := EncryptedMemo{
-		From:      senderPubKey.ToStr(),
-		To:        recipientPubKey.ToStr(),
-		Nonce:     nonce,
-		Check:     checksum,
-		Encrypted: encrypted,
+	// Determine the other party's public key
+	from, to, err := encMemo.keys()
+	if err != nil {
+		return "", err
+	}
+	otherPubKey := from
+	if bytes.Equal(memoSigner.PublicKey().ToByte(), from.ToByte()) {
+		otherPubKey = to
 	}
 
-	// Serialize (simplified - in practice, you'd use the proper serializer)
-	// For now, we'll use a simple base64 encoding
-	memoBytes, err := serializeEncryptedMemo(encMemo)
+	decrypted, err := decryptMemo(memoSigner, otherPubKey, encMemo.Nonce, encMemo.Encrypted, encMemo.Check)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to serialize encrypted memo")
+		return "", errors.Wrap(err, "failed to decrypt memo")
 	}
 
-	// Encode to base58
-	encoded := base58.Encode(memoBytes)
-	return "#" + encoded, nil
+	return "#" + readMemoString(decrypted), nil
 }
 
-// Decode decrypts a memo if it starts with '#', otherwise returns it as-is.
-func Decode(privateKey interface{}, memo string) (string, error) {
-	if memo == "" {
-		return "", errors.New("memo is required")
+// ParseEncryptedMemo decodes an encrypted memo ("#" followed by base58)
+// without decrypting it, so that the embedded public keys can be inspected.
+func ParseEncryptedMemo(memo string) (*EncryptedMemo, error) {
+	if len(memo) < 2 || memo[0] != '#' {
+		return nil, errors.New("memo is not encrypted")
 	}
 
-	// If memo doesn't start with '#', return as-is
-	if len(memo) == 0 || memo[0] != '#' {
-		return memo, nil
+	data := base58.Decode(memo[1:])
+	if len(data) == 0 {
+		return nil, errors.New("invalid base58 memo")
 	}
 
-	// Remove '#' prefix
-	memo = memo[1:]
+	encMemo, err := deserializeEncryptedMemo(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize encrypted memo")
+	}
+	return encMemo, nil
+}
 
-	// Convert private key to PrivateKey object
-	privKey, err := toPrivateKey(privateKey)
+// ParseEncryptedMemoWithConfig is like ParseEncryptedMemo but formats the
+// embedded public keys with the address prefix of the given chain.
+func ParseEncryptedMemoWithConfig(memo string, cfg *consts.ChainConfig) (*EncryptedMemo, error) {
+	encMemo, err := ParseEncryptedMemo(memo)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to convert private key")
+		return nil, err
+	}
+	from, to, err := encMemo.keys()
+	if err != nil {
+		return nil, err
+	}
+	encMemo.From = from.ToStrWithPrefix(cfg.AddressPrefix)
+	encMemo.To = to.ToStrWithPrefix(cfg.AddressPrefix)
+	return encMemo, nil
+}
+
+// keys parses the sender and recipient public keys of the memo.
+func (m *EncryptedMemo) keys() (from, to *wif.PublicKey, err error) {
+	from = &wif.PublicKey{}
+	if err := from.FromStrAnyPrefix(m.From); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse sender public key")
+	}
+	to = &wif.PublicKey{}
+	if err := to.FromStrAnyPrefix(m.To); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse recipient public key")
 	}
+	return from, to, nil
+}
+
+// IsEncryptedMemo reports whether the memo looks like an encrypted memo.
+func IsEncryptedMemo(memo string) bool {
+	_, err := ParseEncryptedMemo(memo)
+	return err == nil
+}
 
-	// Decode from base58
-	memoBytes := base58.Decode(memo)
+// Helper functions
 
-	// Deserialize encrypted memo
-	encMemo, err := deserializeEncryptedMemo(memoBytes)
+func encodeMemo(privateKey interface{}, publicKey interface{}, memo string, nonce uint64) (string, error) {
+	memoSigner, err := toMemoSigner(privateKey)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to deserialize encrypted memo")
+		return "", errors.Wrap(err, "failed to convert private key")
 	}
 
-	// Get sender's public key
-	senderPubKey := &wif.PublicKey{}
-	if err := senderPubKey.FromStr(privKey.ToPubKeyStr()); err != nil {
-		return "", errors.Wrap(err, "failed to get sender public key")
+	pubKey, err := toPublicKey(publicKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to convert public key")
 	}
 
-	// Determine the other party's public key
-	var otherPubKey *wif.PublicKey
-	if senderPubKey.ToStr() == encMemo.From {
-		otherPubKey = &wif.PublicKey{}
-		if err := otherPubKey.FromStr(encMemo.To); err != nil {
-			return "", errors.Wrap(err, "failed to parse recipient public key")
-		}
-	} else {
-		otherPubKey = &wif.PublicKey{}
-		if err := otherPubKey.FromStr(encMemo.From); err != nil {
-			return "", errors.Wrap(err, "failed to parse sender public key")
-		}
+	// The plaintext is serialized as a string, i.e. with a varint length prefix.
+	plain := []byte(memo[1:])
+	message := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(plain))
+	message = append(message[:binary.PutUvarint(message, uint64(len(plain)))], plain...)
+
+	encrypted, checksum, err := encryptMemo(memoSigner, pubKey, nonce, message)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encrypt memo")
 	}
 
-	// Decrypt the memo
-	decrypted, err := decryptMemo(privKey, otherPubKey, encMemo.Nonce, encMemo.Encrypted, encMemo.Check)
+	memoBytes, err := serializeEncryptedMemo(memoSigner.PublicKey(), pubKey, nonce, checksum, encrypted)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to decrypt memo")
+		return "", errors.Wrap(err, "failed to serialize encrypted memo")
 	}
 
-	// Return with '#' prefix
-	return "#" + string(decrypted), nil
+	return "#" + base58.Encode(memoBytes), nil
 }
 
-// Helper functions
-
 func toPrivateKey(key interface{}) (*wif.PrivateKey, error) {
 	switch v := key.(type) {
 	case *wif.PrivateKey:
@@ -166,13 +194,24 @@ func toPrivateKey(key interface{}) (*wif.PrivateKey, error) {
 	}
 }
 
+func toMemoSigner(key interface{}) (signer.SharedSecretSigner, error) {
+	if s, ok := key.(signer.SharedSecretSigner); ok {
+		return s, nil
+	}
+	privKey, err := toPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return signer.NewKeySigner(privKey), nil
+}
+
 func toPublicKey(key interface{}) (*wif.PublicKey, error) {
 	switch v := key.(type) {
 	case *wif.PublicKey:
 		return v, nil
 	case string:
 		pubKey := &wif.PublicKey{}
-		if err := pubKey.FromStr(v); err != nil {
+		if err := pubKey.FromStrAnyPrefix(v); err != nil {
 			return nil, err
 		}
 		return pubKey, nil
@@ -181,90 +220,86 @@ func toPublicKey(key interface{}) (*wif.PublicKey, error) {
 	}
 }
 
-// encryptMemo encrypts a memo using AES-256-CBC with shared secret derived from ECDH.
-func encryptMemo(privKey *wif.PrivateKey, pubKey *wif.PublicKey, message []byte) ([]byte, []byte, []byte, error) {
-	// Generate shared secret using ECDH
-	sharedSecret := deriveSharedSecret(privKey, pubKey)
+// uniqueNonce returns a random 64-bit nonce.
+func uniqueNonce() (uint64, error) {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return 0, errors.Wrap(err, "failed to generate nonce")
+	}
+	return binary.LittleEndian.Uint64(buf[:]), nil
+}
 
-	// Generate random nonce
-	nonce := make([]byte, 16)
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return nil, nil, nil, err
+// memoKey derives the AES key, IV and checksum for a memo:
+// sha512(nonce || sha512(ecdh_x)) gives the key (first 32 bytes) and the IV
+// (next 16 bytes); the first 4 bytes of sha256 over it are the checksum.
+func memoKey(memoSigner signer.SharedSecretSigner, pubKey *wif.PublicKey, nonce uint64) (key, iv []byte, check uint32, err error) {
+	secret, err := memoSigner.SharedSecret(pubKey)
+	if err != nil {
+		return nil, nil, 0, errors.Wrap(err, "failed to compute shared secret")
 	}
 
-	// Create AES cipher
-	block, err := aes.NewCipher(sharedSecret[:32])
+	buf := make([]byte, 8, 8+len(secret))
+	binary.LittleEndian.PutUint64(buf, nonce)
+	buf = append(buf, secret...)
+
+	encryptionKey := sha512.Sum512(buf)
+	checksum := sha256.Sum256(encryptionKey[:])
+
+	return encryptionKey[:32], encryptionKey[32:48], binary.LittleEndian.Uint32(checksum[:4]), nil
+}
+
+// encryptMemo encrypts a memo using AES-256-CBC.
+func encryptMemo(memoSigner signer.SharedSecretSigner, pubKey *wif.PublicKey, nonce uint64, message []byte) ([]byte, uint32, error) {
+	key, iv, check, err := memoKey(memoSigner, pubKey, nonce)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, 0, err
 	}
 
-	// Use CBC mode
-	iv := nonce
-	mode := cipher.NewCBCEncrypter(block, iv)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, 0, err
+	}
 
-	// Pad message
 	padded := pkcs7Pad(message, aes.BlockSize)
-
-	// Encrypt
 	encrypted := make([]byte, len(padded))
-	mode.CryptBlocks(encrypted, padded)
-
-	// Calculate checksum (first 4 bytes of SHA256 of encrypted data)
-	hash := sha256.Sum256(encrypted)
-	checksum := hash[:4]
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(encrypted, padded)
 
-	return encrypted, nonce, checksum, nil
+	return encrypted, check, nil
 }
 
 // decryptMemo decrypts a memo using AES-256-CBC.
-func decryptMemo(privKey *wif.PrivateKey, pubKey *wif.PublicKey, nonce, encrypted, checksum []byte) ([]byte, error) {
-	// Verify checksum
-	hash := sha256.Sum256(encrypted)
-	if len(checksum) < 4 {
-		return nil, errors.New("invalid checksum length")
-	}
-	for i := 0; i < 4; i++ {
-		if hash[i] != checksum[i] {
-			return nil, errors.New("checksum mismatch")
-		}
-	}
-
-	// Generate shared secret using ECDH
-	sharedSecret := deriveSharedSecret(privKey, pubKey)
-
-	// Create AES cipher
-	block, err := aes.NewCipher(sharedSecret[:32])
+func decryptMemo(memoSigner signer.SharedSecretSigner, pubKey *wif.PublicKey, nonce uint64, encrypted []byte, checksum uint32) ([]byte, error) {
+	key, iv, check, err := memoKey(memoSigner, pubKey, nonce)
 	if err != nil {
 		return nil, err
 	}
+	if check != checksum {
+		return nil, errors.New("checksum mismatch")
+	}
 
-	// Use CBC mode
-	iv := nonce
-	mode := cipher.NewCBCDecrypter(block, iv)
-
-	// Decrypt
-	decrypted := make([]byte, len(encrypted))
-	mode.CryptBlocks(decrypted, encrypted)
+	if len(encrypted) == 0 || len(encrypted)%aes.BlockSize != 0 {
+		return nil, errors.New("invalid ciphertext length")
+	}
 
-	// Remove padding
-	unpadded, err := pkcs7Unpad(decrypted)
+	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, err
 	}
 
-	return unpadded, nil
+	decrypted := make([]byte, len(encrypted))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(decrypted, encrypted)
+
+	return pkcs7Unpad(decrypted)
 }
 
-// deriveSharedSecret derives a shared secret using ECDH.
-func deriveSharedSecret(privKey *wif.PrivateKey, pubKey *wif.PublicKey) []byte {
-	// ECDH: shared secret = privKey * pubKey
-	// This is a simplified version - in practice, you'd use proper ECDH
-	// For now, we'll use a combination of both keys
-	privBytes := privKey.ToByte()
-	pubBytes := pubKey.ToByte()
-	combined := append(privBytes, pubBytes...)
-	hash := sha256.Sum256(combined)
-	return hash[:]
+// readMemoString reads the varint-prefixed plaintext. Memos written by old
+// clients carry the plaintext without a prefix and are returned as is.
+func readMemoString(data []byte) string {
+	n, k := binary.Uvarint(data)
+	if k <= 0 || n > uint64(len(data)-k) {
+		return string(data)
+	}
+	return string(data[k : k+int(n)])
 }
 
 // pkcs7Pad adds PKCS7 padding to data.
@@ -294,75 +329,54 @@ func pkcs7Unpad(data []byte) ([]byte, error) {
 	return data[:len(data)-padding], nil
 }
 
-// serializeEncryptedMemo serializes an encrypted memo (simplified version).
-func serializeEncryptedMemo(memo EncryptedMemo) ([]byte, error) {
-	// This is a simplified serialization
-	// In practice, you'd use the proper Steem serializer
-	// For now, we'll use a simple format: from|to|nonce|check|encrypted
-	fromBytes := []byte(memo.From)
-	toBytes := []byte(memo.To)
-
-	result := make([]byte, 0, len(fromBytes)+len(toBytes)+len(memo.Nonce)+len(memo.Check)+len(memo.Encrypted)+20)
-	result = append(result, byte(len(fromBytes)))
-	result = append(result, fromBytes...)
-	result = append(result, byte(len(toBytes)))
-	result = append(result, toBytes...)
-	result = append(result, byte(len(memo.Nonce)))
-	result = append(result, memo.Nonce...)
-	result = append(result, byte(len(memo.Check)))
-	result = append(result, memo.Check...)
-	result = append(result, memo.Encrypted...)
-	return result, nil
+// serializeEncryptedMemo writes the memo in its binary form: two 33-byte
+// public keys, the nonce as uint64, the checksum as uint32 and the
+// ciphertext with a varint length prefix.
+func serializeEncryptedMemo(from, to *wif.PublicKey, nonce uint64, check uint32, encrypted []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(from.ToByte())
+	buf.Write(to.ToByte())
+	if err := binary.Write(&buf, binary.LittleEndian, nonce); err != nil {
+		return nil, err
+	}
+	if err := binary.Write(&buf, binary.LittleEndian, check); err != nil {
+		return nil, err
+	}
+	length := make([]byte, binary.MaxVarintLen64)
+	buf.Write(length[:binary.PutUvarint(length, uint64(len(encrypted)))])
+	buf.Write(encrypted)
+	return buf.Bytes(), nil
 }
 
-// deserializeEncryptedMemo deserializes an encrypted memo (simplified version).
-func deserializeEncryptedMemo(data []byte) (EncryptedMemo, error) {
-	// This is a simplified deserialization
-	// In practice, you'd use the proper Steem deserializer
-	if len(data) < 5 {
-		return EncryptedMemo{}, errors.New("data too short")
+// deserializeEncryptedMemo reads the binary form written by serializeEncryptedMemo.
+func deserializeEncryptedMemo(data []byte) (*EncryptedMemo, error) {
+	const headerLen = 33 + 33 + 8 + 4
+	if len(data) < headerLen+1 {
+		return nil, errors.New("data too short")
 	}
 
-	offset := 0
-	fromLen := int(data[offset])
-	offset++
-	if offset+fromLen > len(data) {
-		return EncryptedMemo{}, errors.New("invalid from length")
+	from := &wif.PublicKey{}
+	if err := from.FromByte(data[0:33]); err != nil {
+		return nil, errors.Wrap(err, "invalid from key")
 	}
-	from := string(data[offset : offset+fromLen])
-	offset += fromLen
-
-	toLen := int(data[offset])
-	offset++
-	if offset+toLen > len(data) {
-		return EncryptedMemo{}, errors.New("invalid to length")
+	to := &wif.PublicKey{}
+	if err := to.FromByte(data[33:66]); err != nil {
+		return nil, errors.Wrap(err, "invalid to key")
 	}
-	to := string(data[offset : offset+toLen])
-	offset += toLen
 
-	nonceLen := int(data[offset])
-	offset++
-	if offset+nonceLen > len(data) {
-		return EncryptedMemo{}, errors.New("invalid nonce length")
-	}
-	nonce := make([]byte, nonceLen)
-	copy(nonce, data[offset:offset+nonceLen])
-	offset += nonceLen
+	nonce := binary.LittleEndian.Uint64(data[66:74])
+	check := binary.LittleEndian.Uint32(data[74:78])
 
-	checkLen := int(data[offset])
-	offset++
-	if offset+checkLen > len(data) {
-		return EncryptedMemo{}, errors.New("invalid check length")
+	n, k := binary.Uvarint(data[headerLen:])
+	if k <= 0 || n != uint64(len(data)-headerLen-k) {
+		return nil, errors.New("invalid encrypted length")
 	}
-	check := make([]byte, checkLen)
-	copy(check, data[offset:offset+checkLen])
-	offset += checkLen
-
-	encrypted := data[offset:]
+	encrypted := make([]byte, n)
+	copy(encrypted, data[headerLen+k:])
 
-	return EncryptedMemo{
-		From:      from,
-		To:        to,
+	return &EncryptedMemo{
+		From:      from.ToStr(),
+		To:        to.ToStr(),
 		Nonce:     nonce,
 		Check:     check,
 		Encrypted: encrypted,
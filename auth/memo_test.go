@@ -1,15 +1,19 @@
 package auth
 
 import (
+	"crypto/sha256"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/steemit/steemutil/consts"
+	"github.com/steemit/steemutil/signer"
 	"github.com/steemit/steemutil/wif"
 )
 
 func TestEncodeDecodePlainText(t *testing.T) {
 	// Plain text should be returned as-is
 	memo := "plain text memo"
-	
+
 	encoded, err := Encode(nil, nil, memo)
 	if err != nil {
 		t.Fatalf("Encode failed: %v", err)
@@ -97,7 +101,7 @@ func TestEncodeDecodeWithPrivateKeyObject(t *testing.T) {
 
 func TestEncodeDecodeEmptyMemo(t *testing.T) {
 	memo := ""
-	
+
 	encoded, err := Encode(nil, nil, memo)
 	if err == nil {
 		t.Error("Encode should fail for empty memo")
@@ -112,3 +116,162 @@ func TestEncodeDecodeEmptyMemo(t *testing.T) {
 	_ = decoded
 }
 
+// Vectors from steem-js test/memo.test.js, using PrivateKey.fromSeed("").
+func memoTestKey(t *testing.T) *wif.PrivateKey {
+	seed := sha256.Sum256([]byte(""))
+	privKey := &wif.PrivateKey{}
+	if err := privKey.FromByte(seed[:]); err != nil {
+		t.Fatalf("Failed to create private key: %v", err)
+	}
+	return privKey
+}
+
+func TestEncodeKnownEncryption(t *testing.T) {
+	privKey := memoTestKey(t)
+	expected := "#HU6pdQ4Hh8cFrDVooekRPVZu4BdrhAe9RxrWrei2CwfAApAPdM4PT5mSV9cV3tTuWKotYQF6suyM4JHFBZz4pcwyezPzuZ2na7uwhRcLqFoqCam1VU3eCLjVNqcgUNbH3"
+
+	encoded, err := encodeMemo(privKey, privKey.ToPublicKey(), "#爱", 1462976530069648)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if encoded != expected {
+		t.Errorf("expected %s, got %s", expected, encoded)
+	}
+
+	decoded, err := Decode(privKey, expected)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if decoded != "#爱" {
+		t.Errorf("expected %s, got %s", "#爱", decoded)
+	}
+}
+
+func TestEncodeDecodeSenderAndRecipient(t *testing.T) {
+	sender, err := wif.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipient, err := wif.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stranger, err := wif.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	memo := "#memo爱"
+	encoded, err := Encode(sender, recipient.ToPubKeyStr(), memo)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	encMemo, err := ParseEncryptedMemo(encoded)
+	if err != nil {
+		t.Fatalf("ParseEncryptedMemo failed: %v", err)
+	}
+	if encMemo.From != sender.ToPubKeyStr() || encMemo.To != recipient.ToPubKeyStr() {
+		t.Errorf("unexpected memo keys: %s -> %s", encMemo.From, encMemo.To)
+	}
+
+	for _, key := range []*wif.PrivateKey{sender, recipient} {
+		decoded, err := Decode(key, encoded)
+		if err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+		if decoded != memo {
+			t.Errorf("expected %s, got %s", memo, decoded)
+		}
+	}
+
+	if _, err := Decode(stranger, encoded); err == nil {
+		t.Error("Decode should fail with an unrelated key")
+	}
+}
+
+func TestDecodeUnprefixedPlaintext(t *testing.T) {
+	privKey := memoTestKey(t)
+
+	// Old clients encrypted the plaintext without a length prefix.
+	encrypted, check, err := encryptMemo(signer.NewKeySigner(privKey), privKey.ToPublicKey(), 1, []byte("legacy memo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := serializeEncryptedMemo(privKey.ToPublicKey(), privKey.ToPublicKey(), 1, check, encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := Decode(privKey, "#"+base58.Encode(data))
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if decoded != "#legacy memo" {
+		t.Errorf("expected %s, got %s", "#legacy memo", decoded)
+	}
+}
+
+func TestParseEncryptedMemoInvalid(t *testing.T) {
+	for _, memo := range []string{"plain", "#", "#abc", "#HU6pdQ4Hh8cFrDVooekRPVZu4Bdr"} {
+		if IsEncryptedMemo(memo) {
+			t.Errorf("expected %q to be rejected", memo)
+		}
+	}
+}
+
+func TestEncodeDecodeWithSigner(t *testing.T) {
+	privKey := memoTestKey(t)
+	s := signer.NewKeySigner(privKey)
+
+	expected := "#HU6pdQ4Hh8cFrDVooekRPVZu4BdrhAe9RxrWrei2CwfAApAPdM4PT5mSV9cV3tTuWKotYQF6suyM4JHFBZz4pcwyezPzuZ2na7uwhRcLqFoqCam1VU3eCLjVNqcgUNbH3"
+	encoded, err := encodeMemo(s, privKey.ToPublicKey(), "#爱", 1462976530069648)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if encoded != expected {
+		t.Errorf("expected %s, got %s", expected, encoded)
+	}
+
+	decoded, err := Decode(s, encoded)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if decoded != "#爱" {
+		t.Errorf("expected %s, got %s", "#爱", decoded)
+	}
+}
+
+func TestEncodeDecodeTestnetKeys(t *testing.T) {
+	sender, err := wif.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipient, err := wif.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := recipient.ToPublicKey().ToStrWithPrefix(consts.SteemTestnet.AddressPrefix)
+
+	memo := "#testnet memo"
+	encoded, err := Encode(sender, to, memo)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	encMemo, err := ParseEncryptedMemoWithConfig(encoded, consts.SteemTestnet)
+	if err != nil {
+		t.Fatalf("ParseEncryptedMemoWithConfig failed: %v", err)
+	}
+	if encMemo.To != to {
+		t.Errorf("expected %s, got %s", to, encMemo.To)
+	}
+
+	decoded, err := Decode(recipient, encoded)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if decoded != memo {
+		t.Errorf("expected %s, got %s", memo, decoded)
+	}
+}
@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/signer"
+	"github.com/steemit/steemutil/wif"
+)
+
+// ErrNoMemoKey is returned when none of the wallet's keys matches the public
+// keys embedded in an encrypted memo.
+var ErrNoMemoKey = errors.New("no key in the wallet matches the memo")
+
+// MemoWallet decrypts memos with whichever of its private keys matches the
+// memo, typically an account's memo, active and owner keys.
+type MemoWallet struct {
+	// keys is indexed by the compressed public key, so that keys match
+	// regardless of their address prefix.
+	keys map[string]signer.SharedSecretSigner
+}
+
+// MemoResult is the outcome of decrypting the memo of one operation.
+type MemoResult struct {
+	// Index is the position of the operation in the input slice.
+	Index     int
+	Operation protocol.Operation
+	Memo      string
+
+	// Encrypted is false for plain text memos, which are returned as is.
+	Encrypted bool
+	Decrypted string
+
+	// Key is the public key that decrypted the memo.
+	Key string
+	Err error
+}
+
+// NewMemoWallet creates a wallet holding the given keys.
+func NewMemoWallet(keys ...*wif.PrivateKey) *MemoWallet {
+	w := &MemoWallet{keys: make(map[string]signer.SharedSecretSigner, len(keys))}
+	for _, key := range keys {
+		w.AddKey(key)
+	}
+	return w
+}
+
+// AddKey adds a private key to the wallet.
+func (w *MemoWallet) AddKey(key *wif.PrivateKey) {
+	w.AddSigner(signer.NewKeySigner(key))
+}
+
+// AddSigner adds a key held by a signer, e.g. a remote signer, to the wallet.
+func (w *MemoWallet) AddSigner(s signer.SharedSecretSigner) {
+	w.keys[string(s.PublicKey().ToByte())] = s
+}
+
+// AddWif adds a private key in WIF format to the wallet.
+func (w *MemoWallet) AddWif(privWif string) error {
+	key, err := toPrivateKey(privWif)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode WIF")
+	}
+	w.AddKey(key)
+	return nil
+}
+
+// KeyFor returns the key matching the sender or recipient public key of an
+// encrypted memo.
+func (w *MemoWallet) KeyFor(memo string) (signer.SharedSecretSigner, error) {
+	encMemo, err := ParseEncryptedMemo(memo)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := encMemo.keys()
+	if err != nil {
+		return nil, err
+	}
+	if key, ok := w.keys[string(to.ToByte())]; ok {
+		return key, nil
+	}
+	if key, ok := w.keys[string(from.ToByte())]; ok {
+		return key, nil
+	}
+	return nil, ErrNoMemoKey
+}
+
+// Decode decrypts a memo with the matching key of the wallet. Plain text
+// memos are returned as is.
+func (w *MemoWallet) Decode(memo string) (string, error) {
+	result := w.decode(memo)
+	return result.Decrypted, result.Err
+}
+
+// DecodeOperations decrypts the memos of transfer, savings and escrow
+// operations in bulk. Operations without a memo are skipped. It returns the
+// decoded memos and, separately, the memos no key could decrypt.
+//
+// escrow_transfer has no memo field, so its json_meta is decrypted when it
+// holds an encrypted memo.
+func (w *MemoWallet) DecodeOperations(ops []protocol.Operation) (decoded, failed []MemoResult) {
+	for i, op := range ops {
+		memo, ok := operationMemo(op)
+		if !ok || memo == "" {
+			continue
+		}
+
+		result := w.decode(memo)
+		result.Index = i
+		result.Operation = op
+		if result.Err != nil {
+			failed = append(failed, result)
+		} else {
+			decoded = append(decoded, result)
+		}
+	}
+	return decoded, failed
+}
+
+func (w *MemoWallet) decode(memo string) MemoResult {
+	result := MemoResult{Memo: memo}
+	if memo == "" || memo[0] != '#' {
+		result.Decrypted = memo
+		return result
+	}
+
+	result.Encrypted = true
+	key, err := w.KeyFor(memo)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Key = key.PublicKey().ToStr()
+	result.Decrypted, result.Err = Decode(key, memo)
+	return result
+}
+
+// operationMemo returns the memo carried by the operation, if any.
+func operationMemo(op protocol.Operation) (string, bool) {
+	switch op := op.(type) {
+	case *protocol.TransferOperation:
+		return op.Memo, true
+	case *protocol.TransferToSavingsOperation:
+		return op.Memo, true
+	case *protocol.TransferFromSavingsOperation:
+		return op.Memo, true
+	case *protocol.FillTransferFromSavingsOperation:
+		return op.Memo, true
+	case *protocol.EscrowTransferOperation:
+		if IsEncryptedMemo(op.JsonMeta) {
+			return op.JsonMeta, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/wif"
+)
+
+func TestMemoWallet_DecodeOperations(t *testing.T) {
+	alice, err := GenerateAccountKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := GenerateAccountKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	carol, err := wif.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encode := func(from *wif.PrivateKey, to *wif.PrivateKey, memo string) string {
+		encoded, err := Encode(from, to.ToPublicKey(), memo)
+		if err != nil {
+			t.Fatalf("Encode failed: %v", err)
+		}
+		return encoded
+	}
+
+	ops := []protocol.Operation{
+		&protocol.TransferOperation{From: "bob", To: "alice", Amount: "1.000 STEEM", Memo: encode(bob.Memo, alice.Memo, "#to alice")},
+		&protocol.VoteOperation{Voter: "alice", Author: "bob", Permlink: "post", Weight: 10000},
+		&protocol.TransferToSavingsOperation{From: "alice", To: "bob", Amount: "1.000 SBD", Memo: encode(alice.Active, bob.Memo, "#from alice")},
+		&protocol.TransferOperation{From: "bob", To: "carol", Amount: "1.000 STEEM", Memo: encode(bob.Memo, carol, "#to carol")},
+		&protocol.TransferFromSavingsOperation{From: "alice", To: "alice", Amount: "1.000 SBD", Memo: "plain"},
+		&protocol.TransferOperation{From: "bob", To: "alice", Amount: "1.000 STEEM"},
+		&protocol.EscrowTransferOperation{From: "bob", To: "alice", JsonMeta: encode(bob.Memo, alice.Owner, "#escrow")},
+	}
+
+	wallet := NewMemoWallet(alice.Memo, alice.Active)
+	if err := wallet.AddWif(alice.Owner.ToWif()); err != nil {
+		t.Fatalf("AddWif failed: %v", err)
+	}
+
+	decoded, failed := wallet.DecodeOperations(ops)
+
+	expected := map[int]string{0: "#to alice", 2: "#from alice", 4: "plain", 6: "#escrow"}
+	if len(decoded) != len(expected) {
+		t.Fatalf("expected %d decoded memos, got %d", len(expected), len(decoded))
+	}
+	for _, result := range decoded {
+		if result.Decrypted != expected[result.Index] {
+			t.Errorf("expected %v, got %v", expected[result.Index], result.Decrypted)
+		}
+	}
+	if decoded[1].Key != alice.Active.ToPubKeyStr() {
+		t.Errorf("expected %v, got %v", alice.Active.ToPubKeyStr(), decoded[1].Key)
+	}
+	if decoded[2].Encrypted {
+		t.Error("expected plain text memo not to be marked as encrypted")
+	}
+
+	if len(failed) != 1 || failed[0].Index != 3 || failed[0].Err != ErrNoMemoKey {
+		t.Errorf("unexpected failures: %+v", failed)
+	}
+}
+
+func TestMemoWallet_Decode(t *testing.T) {
+	key, err := wif.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := Encode(key, key.ToPublicKey(), "#self")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewMemoWallet().Decode(encoded); err != ErrNoMemoKey {
+		t.Errorf("expected %v, got %v", ErrNoMemoKey, err)
+	}
+
+	decoded, err := NewMemoWallet(key).Decode(encoded)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if decoded != "#self" {
+		t.Errorf("expected %v, got %v", "#self", decoded)
+	}
+}
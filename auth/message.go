@@ -0,0 +1,259 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/signer"
+	"github.com/steemit/steemutil/wif"
+)
+
+// MessageDomainTag separates message digests from transaction digests and
+// signed RPC requests. It is sha256("steem_signed_message").
+var MessageDomainTag = sha256.Sum256([]byte("steem_signed_message"))
+
+// DefaultMessageLifetime is the validity of messages created by NewMessage.
+const DefaultMessageLifetime = 10 * time.Minute
+
+const messageHeaderSuffix = " wants you to sign in with your Steem account:"
+
+// Errors returned by VerifyMessage.
+var (
+	ErrMessageExpired     = errors.New("message expired")
+	ErrMessageNotYetValid = errors.New("message not yet valid")
+	ErrMessageDomain      = errors.New("message was issued for another domain")
+	ErrMessageWeight      = errors.New("signatures do not satisfy the authority")
+)
+
+// Message is an off-chain "sign in with Steem" statement. Its text form is
+//
+//	example.com wants you to sign in with your Steem account:
+//	alice
+//
+//	Optional statement
+//
+//	Authority: posting
+//	Nonce: 9f2c4e61a0b3d857
+//	Issued At: 2024-01-02T15:04:05Z
+//	Expiration Time: 2024-01-02T15:14:05Z
+type Message struct {
+	Domain    string
+	Account   string
+	Statement string
+	Authority string
+	Nonce     string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// SignedMessage is a message text with the signatures over its digest.
+type SignedMessage struct {
+	Message    string   `json:"message"`
+	Signatures []string `json:"signatures"`
+}
+
+// NewMessage creates a posting authority message for the domain and account
+// with a random nonce, valid for DefaultMessageLifetime.
+func NewMessage(domain, account string) (*Message, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	return &Message{
+		Domain:    domain,
+		Account:   account,
+		Authority: RolePosting,
+		Nonce:     hex.EncodeToString(nonce),
+		IssuedAt:  now,
+		ExpiresAt: now.Add(DefaultMessageLifetime),
+	}, nil
+}
+
+// Validate checks that the message can be represented in the text form.
+func (m *Message) Validate() error {
+	switch {
+	case m.Domain == "" || strings.ContainsAny(m.Domain, " \n"):
+		return errors.Errorf("invalid domain: %q", m.Domain)
+	case m.Account == "" || strings.ContainsAny(m.Account, " \n"):
+		return errors.Errorf("invalid account: %q", m.Account)
+	case strings.Contains(m.Statement, "\n") || strings.HasPrefix(m.Statement, "Authority: "):
+		return errors.New("statement must be a single line of free text")
+	case m.Authority != RolePosting && m.Authority != RoleActive:
+		return errors.Errorf("authority must be %s or %s, got %q", RolePosting, RoleActive, m.Authority)
+	case len(m.Nonce) < 8 || strings.ContainsAny(m.Nonce, " \n"):
+		return errors.New("nonce must have at least 8 characters")
+	case m.IssuedAt.IsZero() || m.ExpiresAt.IsZero():
+		return errors.New("issued at and expiration time are required")
+	case !m.ExpiresAt.After(m.IssuedAt):
+		return errors.New("expiration time must be after issued at")
+	}
+	return nil
+}
+
+// String returns the text form of the message, which is what gets signed.
+func (m *Message) String() string {
+	var b strings.Builder
+	b.WriteString(m.Domain + messageHeaderSuffix + "\n")
+	b.WriteString(m.Account + "\n\n")
+	if m.Statement != "" {
+		b.WriteString(m.Statement + "\n\n")
+	}
+	b.WriteString("Authority: " + m.Authority + "\n")
+	b.WriteString("Nonce: " + m.Nonce + "\n")
+	b.WriteString("Issued At: " + m.IssuedAt.UTC().Format(time.RFC3339) + "\n")
+	b.WriteString("Expiration Time: " + m.ExpiresAt.UTC().Format(time.RFC3339))
+	return b.String()
+}
+
+// ParseMessage parses the text form of a message.
+func ParseMessage(text string) (*Message, error) {
+	lines := strings.Split(text, "\n")
+	if len(lines) < 7 || !strings.HasSuffix(lines[0], messageHeaderSuffix) || lines[2] != "" {
+		return nil, errors.New("invalid message header")
+	}
+
+	m := &Message{
+		Domain:  strings.TrimSuffix(lines[0], messageHeaderSuffix),
+		Account: lines[1],
+	}
+	fields := lines[3:]
+	if !strings.HasPrefix(fields[0], "Authority: ") {
+		if len(fields) < 6 || fields[1] != "" {
+			return nil, errors.New("invalid message statement")
+		}
+		m.Statement = fields[0]
+		fields = fields[2:]
+	}
+	if len(fields) != 4 {
+		return nil, errors.New("invalid message fields")
+	}
+
+	values := make([]string, len(fields))
+	for i, name := range []string{"Authority", "Nonce", "Issued At", "Expiration Time"} {
+		prefix := name + ": "
+		if !strings.HasPrefix(fields[i], prefix) {
+			return nil, errors.Errorf("missing message field: %s", name)
+		}
+		values[i] = strings.TrimPrefix(fields[i], prefix)
+	}
+	m.Authority = values[0]
+	m.Nonce = values[1]
+
+	var err error
+	if m.IssuedAt, err = time.Parse(time.RFC3339, values[2]); err != nil {
+		return nil, errors.Wrap(err, "invalid issued at")
+	}
+	if m.ExpiresAt, err = time.Parse(time.RFC3339, values[3]); err != nil {
+		return nil, errors.Wrap(err, "invalid expiration time")
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Digest returns sha256(MessageDomainTag || sha256(text)).
+func (m *Message) Digest() []byte {
+	return messageDigest(m.String())
+}
+
+func messageDigest(text string) []byte {
+	textHash := sha256.Sum256([]byte(text))
+	digest := sha256.New()
+	digest.Write(MessageDomainTag[:])
+	digest.Write(textHash[:])
+	return digest.Sum(nil)
+}
+
+// Sign signs the message with the given signers, typically the account's
+// posting or active key.
+func (m *Message) Sign(signers ...signer.Signer) (*SignedMessage, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	if len(signers) == 0 {
+		return nil, errors.New("no signers given")
+	}
+
+	digest := m.Digest()
+	signed := &SignedMessage{Message: m.String()}
+	for _, s := range signers {
+		sig, err := s.SignDigest(digest)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to sign message")
+		}
+		signed.Signatures = append(signed.Signatures, hex.EncodeToString(sig))
+	}
+	return signed, nil
+}
+
+// RecoverKeys returns the public keys that signed the message.
+func (sm *SignedMessage) RecoverKeys() ([]*wif.PublicKey, error) {
+	digest := messageDigest(sm.Message)
+	keys := make([]*wif.PublicKey, 0, len(sm.Signatures))
+	for i, sigHex := range sm.Signatures {
+		sig, err := hex.DecodeString(sigHex)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid signature at index %d", i)
+		}
+		key, err := wif.RecoverPublicKeyFromSignature(digest, sig)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid signature at index %d", i)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// VerifyMessage checks that the signed message was issued for domain, is
+// valid at now, and that its signatures satisfy the authority it names, or
+// a higher one, fetched through lookup. It returns the parsed message; callers should also
+// reject nonces they have seen before.
+func VerifyMessage(sm *SignedMessage, domain string, lookup AuthorityLookup, now time.Time) (*Message, error) {
+	m, err := ParseMessage(sm.Message)
+	if err != nil {
+		return nil, err
+	}
+	if m.Domain != domain {
+		return nil, errors.Wrapf(ErrMessageDomain, "expected %s, got %s", domain, m.Domain)
+	}
+	if now.Before(m.IssuedAt) {
+		return nil, ErrMessageNotYetValid
+	}
+	if !now.Before(m.ExpiresAt) {
+		return nil, ErrMessageExpired
+	}
+
+	keys, err := sm.RecoverKeys()
+	if err != nil {
+		return nil, err
+	}
+	// As on chain, a higher authority also satisfies a lower one.
+	for _, role := range messageRoles(m.Authority) {
+		auth, err := lookup.Authority(m.Account, role)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get %s authority of %s", role, m.Account)
+		}
+		if _, ok, err := AuthorityWeight(auth, keys); err != nil {
+			return nil, err
+		} else if ok {
+			return m, nil
+		}
+	}
+	return nil, ErrMessageWeight
+}
+
+// messageRoles returns the roles whose authority satisfies a message naming
+// the given one, starting with it.
+func messageRoles(authority string) []string {
+	if authority == RolePosting {
+		return []string{RolePosting, RoleActive, RoleOwner}
+	}
+	return []string{RoleActive, RoleOwner}
+}
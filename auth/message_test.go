@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/signer"
+	"github.com/steemit/steemutil/wif"
+)
+
+func testMessage(t *testing.T) (*Message, *wif.PrivateKey, AuthorityLookup) {
+	t.Helper()
+	key, err := wif.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	issued := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	m := &Message{
+		Domain:    "example.com",
+		Account:   "alice",
+		Statement: "Sign in to example.com",
+		Authority: RolePosting,
+		Nonce:     "9f2c4e61a0b3d857",
+		IssuedAt:  issued,
+		ExpiresAt: issued.Add(DefaultMessageLifetime),
+	}
+	lookup := AuthorityLookupFunc(func(account, role string) (*protocol.Authority, error) {
+		if account != "alice" {
+			return nil, ErrAccountNotFound
+		}
+		if role != RolePosting {
+			return NewKeyAuthority("STM8m5UgaFAAYQRuaNejYdS8FVLVp9Ss3K1qAVk5de6F8s3HnVbvA"), nil
+		}
+		return NewKeyAuthority(key.ToPubKeyStr()), nil
+	})
+	return m, key, lookup
+}
+
+func TestMessageStringParse(t *testing.T) {
+	m, _, _ := testMessage(t)
+	expected := "example.com wants you to sign in with your Steem account:\n" +
+		"alice\n\n" +
+		"Sign in to example.com\n\n" +
+		"Authority: posting\n" +
+		"Nonce: 9f2c4e61a0b3d857\n" +
+		"Issued At: 2024-01-02T15:04:05Z\n" +
+		"Expiration Time: 2024-01-02T15:14:05Z"
+	if got := m.String(); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	for _, statement := range []string{"Sign in to example.com", ""} {
+		m.Statement = statement
+		parsed, err := ParseMessage(m.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *parsed != *m {
+			t.Errorf("expected %v, got %v", *m, *parsed)
+		}
+	}
+}
+
+func TestParseMessageInvalid(t *testing.T) {
+	m, _, _ := testMessage(t)
+	inputs := []string{
+		"",
+		"example.com wants you to sign in:\nalice",
+		m.String() + "\nExtra: field",
+		m.String()[:len(m.String())-1],
+	}
+	for _, input := range inputs {
+		if _, err := ParseMessage(input); err == nil {
+			t.Errorf("expected an error for %q", input)
+		}
+	}
+}
+
+func TestVerifyMessage(t *testing.T) {
+	m, key, lookup := testMessage(t)
+	signed, err := m.Sign(signer.NewKeySigner(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := m.IssuedAt.Add(time.Minute)
+	verified, err := VerifyMessage(signed, "example.com", lookup, now)
+	if err != nil {
+		t.Fatalf("VerifyMessage failed: %v", err)
+	}
+	if verified.Account != "alice" {
+		t.Errorf("expected alice, got %v", verified.Account)
+	}
+
+	if _, err := VerifyMessage(signed, "evil.com", lookup, now); errors.Cause(err) != ErrMessageDomain {
+		t.Errorf("expected %v, got %v", ErrMessageDomain, err)
+	}
+	if _, err := VerifyMessage(signed, "example.com", lookup, m.ExpiresAt); errors.Cause(err) != ErrMessageExpired {
+		t.Errorf("expected %v, got %v", ErrMessageExpired, err)
+	}
+	if _, err := VerifyMessage(signed, "example.com", lookup, m.IssuedAt.Add(-time.Second)); errors.Cause(err) != ErrMessageNotYetValid {
+		t.Errorf("expected %v, got %v", ErrMessageNotYetValid, err)
+	}
+
+	other, err := wif.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged, err := m.Sign(signer.NewKeySigner(other))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyMessage(forged, "example.com", lookup, now); errors.Cause(err) != ErrMessageWeight {
+		t.Errorf("expected %v, got %v", ErrMessageWeight, err)
+	}
+
+	// A signature over the plain SHA-256 of the text must not verify.
+	sig, err := key.SignMessage([]byte(signed.Message))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := &SignedMessage{Message: signed.Message, Signatures: []string{hex.EncodeToString(sig)}}
+	if _, err := VerifyMessage(plain, "example.com", lookup, now); errors.Cause(err) != ErrMessageWeight {
+		t.Errorf("expected %v, got %v", ErrMessageWeight, err)
+	}
+}
+
+func TestVerifyMessageHigherAuthority(t *testing.T) {
+	m, posting, _ := testMessage(t)
+	keys := map[string]*wif.PrivateKey{RolePosting: posting}
+	for _, role := range []string{RoleActive, RoleOwner} {
+		key, err := wif.GeneratePrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[role] = key
+	}
+	lookup := AuthorityLookupFunc(func(account, role string) (*protocol.Authority, error) {
+		return NewKeyAuthority(keys[role].ToPubKeyStr()), nil
+	})
+	now := m.IssuedAt.Add(time.Minute)
+
+	tests := []struct {
+		authority string
+		role      string
+		ok        bool
+	}{
+		{RolePosting, RolePosting, true},
+		{RolePosting, RoleActive, true},
+		{RolePosting, RoleOwner, true},
+		{RoleActive, RolePosting, false},
+		{RoleActive, RoleActive, true},
+		{RoleActive, RoleOwner, true},
+	}
+	for _, tt := range tests {
+		m.Authority = tt.authority
+		signed, err := m.Sign(signer.NewKeySigner(keys[tt.role]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = VerifyMessage(signed, "example.com", lookup, now)
+		if tt.ok && err != nil {
+			t.Errorf("%s message signed with %s key: expected no error, got %v", tt.authority, tt.role, err)
+		}
+		if !tt.ok && errors.Cause(err) != ErrMessageWeight {
+			t.Errorf("%s message signed with %s key: expected %v, got %v", tt.authority, tt.role, ErrMessageWeight, err)
+		}
+	}
+}
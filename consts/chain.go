@@ -0,0 +1,161 @@
+package consts
+
+// ChainConfig bundles the parameters that differ between Steem networks.
+type ChainConfig struct {
+	Name          string
+	ChainID       string
+	AddressPrefix string
+
+	// Core asset symbols as used in legacy asset strings ("1.000 STEEM").
+	SteemSymbol string
+	SBDSymbol   string
+	VestsSymbol string
+
+	// Core asset NAIs as used in the NAI asset format.
+	SteemNAI string
+	SBDNAI   string
+	VestsNAI string
+
+	SteemPrecision uint8
+	SBDPrecision   uint8
+	VestsPrecision uint8
+
+	// Protocol limits.
+	MinAccountNameLength     int
+	MaxAccountNameLength     int
+	MaxPermlinkLength        int
+	MaxMemoSize              int
+	MaxTransactionSize       int
+	MaxTimeUntilExpiration   int
+	BlockInterval            int
+	MaxSigCheckDepth         int
+	MaxCommentDepth          int
+	MaxAuthorityMembership   int
+	CommentTitleLimit        int
+	MaxVoteChangesPerComment int
+
+	// MinAccountCreationFee is the smallest account_creation_fee a witness
+	// may set, in the smallest unit of the core asset.
+	MinAccountCreationFee int64
+}
+
+// SteemMainnet is the configuration of the Steem main network.
+var SteemMainnet = &ChainConfig{
+	Name:          "mainnet",
+	ChainID:       "0000000000000000000000000000000000000000000000000000000000000000",
+	AddressPrefix: ADDRESS_PREFIX,
+
+	SteemSymbol: "STEEM",
+	SBDSymbol:   "SBD",
+	VestsSymbol: "VESTS",
+
+	SteemNAI: "@@000000021",
+	SBDNAI:   "@@000000013",
+	VestsNAI: "@@000000037",
+
+	SteemPrecision: 3,
+	SBDPrecision:   3,
+	VestsPrecision: 6,
+
+	MinAccountNameLength:     3,
+	MaxAccountNameLength:     16,
+	MaxPermlinkLength:        256,
+	MaxMemoSize:              2048,
+	MaxTransactionSize:       1024 * 64,
+	MaxTimeUntilExpiration:   60 * 60,
+	BlockInterval:            STEEM_BLOCK_INTERVAL,
+	MaxSigCheckDepth:         2,
+	MaxCommentDepth:          0xffff,
+	MaxAuthorityMembership:   40,
+	CommentTitleLimit:        256,
+	MaxVoteChangesPerComment: 5,
+
+	MinAccountCreationFee: 1,
+}
+
+// SteemTestnet is the configuration of a steemd testnet build.
+var SteemTestnet = &ChainConfig{
+	Name:          "testnet",
+	ChainID:       "18dcf0a285365fc58b71f18b3d3fec954aa0c141c44e4e5cb4cf777b9eab274e",
+	AddressPrefix: "TST",
+
+	SteemSymbol: "TESTS",
+	SBDSymbol:   "TBD",
+	VestsSymbol: "VESTS",
+
+	SteemNAI: "@@000000021",
+	SBDNAI:   "@@000000013",
+	VestsNAI: "@@000000037",
+
+	SteemPrecision: 3,
+	SBDPrecision:   3,
+	VestsPrecision: 6,
+
+	MinAccountNameLength:     3,
+	MaxAccountNameLength:     16,
+	MaxPermlinkLength:        256,
+	MaxMemoSize:              2048,
+	MaxTransactionSize:       1024 * 64,
+	MaxTimeUntilExpiration:   60 * 60,
+	BlockInterval:            STEEM_BLOCK_INTERVAL,
+	MaxSigCheckDepth:         2,
+	MaxCommentDepth:          0xffff,
+	MaxAuthorityMembership:   40,
+	CommentTitleLimit:        256,
+	MaxVoteChangesPerComment: 5,
+
+	MinAccountCreationFee: 0,
+}
+
+// KnownChains lists the configurations whose address prefixes are accepted
+// when parsing public keys without an explicit configuration.
+var KnownChains = []*ChainConfig{SteemMainnet, SteemTestnet}
+
+// ChainByPrefix returns the known configuration using the address prefix.
+func ChainByPrefix(prefix string) (*ChainConfig, bool) {
+	for _, cfg := range KnownChains {
+		if cfg.AddressPrefix == prefix {
+			return cfg, true
+		}
+	}
+	return nil, false
+}
+
+// SymbolPrecision returns the precision of a core asset symbol.
+func (cfg *ChainConfig) SymbolPrecision(symbol string) (uint8, bool) {
+	switch symbol {
+	case cfg.SteemSymbol:
+		return cfg.SteemPrecision, true
+	case cfg.SBDSymbol:
+		return cfg.SBDPrecision, true
+	case cfg.VestsSymbol:
+		return cfg.VestsPrecision, true
+	}
+	return 0, false
+}
+
+// SymbolNAI returns the NAI of a core asset symbol.
+func (cfg *ChainConfig) SymbolNAI(symbol string) (string, bool) {
+	switch symbol {
+	case cfg.SteemSymbol:
+		return cfg.SteemNAI, true
+	case cfg.SBDSymbol:
+		return cfg.SBDNAI, true
+	case cfg.VestsSymbol:
+		return cfg.VestsNAI, true
+	}
+	return "", false
+}
+
+// NAISymbol returns the core asset symbol of a NAI.
+func (cfg *ChainConfig) NAISymbol(nai string) (string, bool) {
+	switch nai {
+	case cfg.SteemNAI:
+		return cfg.SteemSymbol, true
+	case cfg.SBDNAI:
+		return cfg.SBDSymbol, true
+	case cfg.VestsNAI:
+		return cfg.VestsSymbol, true
+	}
+	return "", false
+}
@@ -0,0 +1,27 @@
+package consts
+
+import "testing"
+
+func TestChainByPrefix(t *testing.T) {
+	cfg, ok := ChainByPrefix("TST")
+	if !ok || cfg != SteemTestnet {
+		t.Errorf("expected testnet config, got %v", cfg)
+	}
+	if _, ok := ChainByPrefix("XYZ"); ok {
+		t.Error("expected no config for XYZ")
+	}
+}
+
+func TestChainConfigSymbols(t *testing.T) {
+	nai, ok := SteemTestnet.SymbolNAI("TBD")
+	if !ok || nai != "@@000000013" {
+		t.Errorf("expected @@000000013, got %v", nai)
+	}
+	symbol, ok := SteemMainnet.NAISymbol("@@000000037")
+	if !ok || symbol != "VESTS" {
+		t.Errorf("expected VESTS, got %v", symbol)
+	}
+	if _, ok := SteemMainnet.SymbolPrecision("TESTS"); ok {
+		t.Error("expected TESTS to be unknown on mainnet")
+	}
+}
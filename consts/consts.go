@@ -1,3 +1,17 @@
 package consts
 
 const ADDRESS_PREFIX = "STM"
+
+// Chain parameters mirrored from steemd's protocol/config.hpp.
+const (
+	STEEM_100_PERCENT                      = 10000
+	STEEM_1_PERCENT                        = STEEM_100_PERCENT / 100
+	STEEM_VOTING_MANA_REGENERATION_SECONDS = 5 * 60 * 60 * 24
+	STEEM_VOTE_DUST_THRESHOLD              = 50000000
+	STEEM_DEFAULT_VOTE_POWER_RESERVE_RATE  = 10
+	STEEM_DEFAULT_DOWNVOTE_POOL_PERCENT    = 2500
+	STEEM_MIN_PAYOUT_SBD                   = 20
+	STEEM_CONTENT_CONSTANT_HF21            = 2000000000000
+	STEEM_BLOCK_INTERVAL                   = 3
+	STEEM_RC_REGEN_TIME                    = 5 * 60 * 60 * 24
+)
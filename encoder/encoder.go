@@ -124,7 +124,7 @@ func (encoder *Encoder) writeString(s string) error {
 
 // operationInterface represents the Operation interface methods without importing protocol package
 type operationInterface struct {
-	getTypeCode func() uint64
+	getTypeCode func() (uint64, error)
 	getData     func() interface{}
 }
 
@@ -155,20 +155,26 @@ func (encoder *Encoder) checkOperation(v interface{}) *operationInterface {
 		return nil
 	}
 
-	// Create closure to get type code
-	getTypeCode := func() uint64 {
+	// Create closure to get type code. Code() may also return an error as
+	// its second result.
+	getTypeCode := func() (uint64, error) {
 		codeResult := codeMethod.Call(nil)
 		if len(codeResult) == 0 {
-			return 0
+			return 0, errors.New("operation type has no code")
+		}
+		if len(codeResult) > 1 {
+			if err, ok := codeResult[1].Interface().(error); ok && err != nil {
+				return 0, err
+			}
 		}
 		codeValue := codeResult[0]
 		switch codeValue.Kind() {
 		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			return codeValue.Uint()
+			return codeValue.Uint(), nil
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			return uint64(codeValue.Int())
+			return uint64(codeValue.Int()), nil
 		default:
-			return 0
+			return 0, errors.Errorf("unsupported operation code type: %v", codeValue.Kind())
 		}
 	}
 
@@ -191,7 +197,10 @@ func (encoder *Encoder) checkOperation(v interface{}) *operationInterface {
 // It first encodes the operation type code, then encodes all fields in order.
 func (encoder *Encoder) encodeOperation(op *operationInterface) error {
 	// Encode the operation type code
-	code := op.getTypeCode()
+	code, err := op.getTypeCode()
+	if err != nil {
+		return err
+	}
 	if err := encoder.EncodeUVarint(code); err != nil {
 		return errors.Wrap(err, "failed to encode operation type")
 	}
@@ -0,0 +1,240 @@
+// Command apigen generates the jsonrpc2 method stubs and the api response
+// types from api.MethodsData. It is run by go generate in the jsonrpc2
+// package:
+//
+//	go generate ./jsonrpc2
+//
+// Methods with positional params are called through condenser_api, which
+// serves the legacy APIs of the catalogue. Methods whose IsObject is set are
+// called on their own API with the params as an object.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/steemit/steemutil/protocol/api"
+)
+
+// legacyAPI serves the positional methods of every API in the catalogue.
+const legacyAPI = "condenser_api"
+
+// defaultResponse is the response type of methods without a typed model.
+const defaultResponse = "json.RawMessage"
+
+// responseTypes maps "api.method" keys of the catalogue to the api package
+// types their results decode into.
+var responseTypes = map[string]string{
+	"database_api.get_dynamic_global_properties":         "DynamicGlobalProperties",
+	"database_api.get_block":                             "Block",
+	"database_api.get_transaction":                       "Transaction",
+	"database_api.get_accounts":                          "[]Account",
+	"database_api.lookup_account_names":                  "[]Account",
+	"database_api.lookup_accounts":                       "[]string",
+	"database_api.get_feed_history":                      "FeedHistory",
+	"database_api.get_current_median_history_price":      "Price",
+	"database_api.get_open_orders":                       "[]OpenOrder",
+	"database_api.get_active_votes":                      "[]VoteState",
+	"database_api.get_content":                           "Content",
+	"database_api.get_content_replies":                   "[]Content",
+	"database_api.get_discussions_by_author_before_date": "[]Content",
+	"database_api.get_replies_by_last_update":            "[]Content",
+	"database_api.get_post_discussions_by_payout":        "[]Content",
+	"database_api.get_comment_discussions_by_payout":     "[]Content",
+	"database_api.get_discussions_by_trending":           "[]Content",
+	"database_api.get_discussions_by_trending30":         "[]Content",
+	"database_api.get_discussions_by_created":            "[]Content",
+	"database_api.get_discussions_by_active":             "[]Content",
+	"database_api.get_discussions_by_cashout":            "[]Content",
+	"database_api.get_discussions_by_payout":             "[]Content",
+	"database_api.get_discussions_by_votes":              "[]Content",
+	"database_api.get_discussions_by_children":           "[]Content",
+	"database_api.get_discussions_by_hot":                "[]Content",
+	"database_api.get_discussions_by_feed":               "[]Content",
+	"database_api.get_discussions_by_blog":               "[]Content",
+	"database_api.get_discussions_by_comments":           "[]Content",
+	"database_api.get_discussions_by_promoted":           "[]Content",
+	"database_api.get_witnesses":                         "[]Witness",
+	"database_api.get_witness_by_account":                "Witness",
+	"database_api.get_witnesses_by_vote":                 "[]Witness",
+	"database_api.lookup_witness_accounts":               "[]string",
+	"database_api.get_active_witnesses":                  "[]string",
+	"database_api.get_reward_fund":                       "RewardFund",
+	"database_api.get_vesting_delegations":               "[]VestingDelegation",
+	"rc_api.find_rc_accounts":                            "RCAccounts",
+}
+
+// method is an entry of the catalogue prepared for the templates.
+type method struct {
+	api.APIMethod
+	Name     string
+	Wire     string
+	Response string
+	Args     []string
+}
+
+func main() {
+	methodsOut := flag.String("methods", "methods_gen.go", "output file of the jsonrpc2 method stubs")
+	responsesOut := flag.String("responses", "../protocol/api/responses_gen.go", "output file of the api response types")
+	flag.Parse()
+
+	methods, err := prepare(api.MethodsData)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := write(*methodsOut, generateMethods(methods)); err != nil {
+		log.Fatal(err)
+	}
+	if err := write(*responsesOut, generateResponses(methods)); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func write(path string, src []byte) error {
+	out, err := format.Source(src)
+	if err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return os.WriteFile(path, out, 0o644)
+}
+
+// prepare names the methods and checks the catalogue for conflicts.
+func prepare(data []api.APIMethod) ([]method, error) {
+	names := make(map[string]string, len(data))
+	keys := make(map[string]bool, len(data))
+	methods := make([]method, 0, len(data))
+	for _, m := range data {
+		key := m.API + "." + m.Method
+		if keys[key] {
+			return nil, fmt.Errorf("duplicate method %s", key)
+		}
+		keys[key] = true
+
+		name := m.MethodName
+		if name == "" {
+			name = camelCase(m.Method)
+		}
+		name = strings.ToUpper(name[:1]) + name[1:]
+		if prev, ok := names[name]; ok {
+			return nil, fmt.Errorf("%s and %s are both named %s", prev, key, name)
+		}
+		names[name] = key
+
+		wire := legacyAPI + "." + m.Method
+		if m.IsObject {
+			wire = key
+		}
+		response := responseTypes[key]
+		if response == "" {
+			response = defaultResponse
+		}
+		args := make([]string, len(m.Params))
+		for i, p := range m.Params {
+			args[i] = paramName(p)
+		}
+		methods = append(methods, method{
+			APIMethod: m,
+			Name:      name,
+			Wire:      wire,
+			Response:  response,
+			Args:      args,
+		})
+	}
+	for key := range responseTypes {
+		if !keys[key] {
+			return nil, fmt.Errorf("response type of unknown method %s", key)
+		}
+	}
+	return methods, nil
+}
+
+// camelCase converts a snake_case name to camelCase.
+func camelCase(s string) string {
+	parts := strings.Split(s, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// paramName converts a catalogue param name to a Go identifier that does not
+// clash with keywords or the names used by the stubs.
+func paramName(s string) string {
+	name := camelCase(s)
+	switch {
+	case token.IsKeyword(name), name == "j", name == "result", name == "err", name == "api":
+		return name + "Param"
+	}
+	return name
+}
+
+func generateMethods(methods []method) []byte {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by apigen from api.MethodsData. DO NOT EDIT.\n\n")
+	b.WriteString("package jsonrpc2\n\n")
+	b.WriteString("import \"github.com/steemit/steemutil/protocol/api\"\n")
+	for _, m := range methods {
+		fmt.Fprintf(&b, "\n// %s calls %s.\n", m.Name, m.Wire)
+		fmt.Fprintf(&b, "func (j *JsonRpc) %s(", m.Name)
+		b.WriteString(strings.Join(m.Args, ", "))
+		if len(m.Args) > 0 {
+			b.WriteString(" any")
+		}
+		fmt.Fprintf(&b, ") (result api.%sResponse, err error) {\n", m.Name)
+		call := "call"
+		if m.IsObject {
+			call = "callObject"
+		}
+		fmt.Fprintf(&b, "\terr = j.%s(%q, %s, &result)\n", call, m.Wire, paramsExpr(m))
+		b.WriteString("\treturn\n}\n")
+	}
+	return b.Bytes()
+}
+
+// paramsExpr is the Go expression of the params of a call.
+func paramsExpr(m method) string {
+	var b strings.Builder
+	if m.IsObject {
+		b.WriteString("map[string]any{")
+		for i, arg := range m.Args {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "%q: %s", m.Params[i], arg)
+		}
+	} else {
+		b.WriteString("[]any{")
+		b.WriteString(strings.Join(m.Args, ", "))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func generateResponses(methods []method) []byte {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by apigen from api.MethodsData. DO NOT EDIT.\n\n")
+	b.WriteString("package api\n\n")
+	for _, m := range methods {
+		if m.Response == defaultResponse {
+			b.WriteString("import \"encoding/json\"\n\n")
+			break
+		}
+	}
+	b.WriteString("// Responses of the methods of MethodsData. Methods without a typed model\n")
+	b.WriteString("// return the raw result, which can be decoded later.\n")
+	b.WriteString("type (\n")
+	for _, m := range methods {
+		fmt.Fprintf(&b, "\t// %sResponse is the result of %s.%s.\n", m.Name, m.API, m.Method)
+		fmt.Fprintf(&b, "\t%sResponse = %s\n", m.Name, m.Response)
+	}
+	b.WriteString(")\n")
+	return b.Bytes()
+}
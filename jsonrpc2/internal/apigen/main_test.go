@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"os"
+	"testing"
+
+	"github.com/steemit/steemutil/protocol/api"
+)
+
+// TestGeneratedFilesUpToDate fails when MethodsData or the response types
+// changed without running go generate.
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	methods, err := prepare(api.MethodsData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string][]byte{
+		"../../methods_gen.go":                   generateMethods(methods),
+		"../../../protocol/api/responses_gen.go": generateResponses(methods),
+	}
+	for path, src := range files {
+		want, err := format.Source(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date, run go generate ./jsonrpc2", path)
+		}
+	}
+}
+
+func TestPrepare(t *testing.T) {
+	methods, err := prepare(api.MethodsData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]method, len(methods))
+	for _, m := range methods {
+		byName[m.Name] = m
+	}
+	tests := []struct {
+		name, wire, response, params string
+	}{
+		{"GetAccounts", "condenser_api.get_accounts", "[]Account", "[]any{names}"},
+		{"GetMarketOrderBook", "condenser_api.get_order_book", "json.RawMessage", "[]any{limit}"},
+		{"FindRcAccounts", "rc_api.find_rc_accounts", "RCAccounts", `map[string]any{"accounts": accounts}`},
+		{"GetVersion", "condenser_api.get_version", "json.RawMessage", "[]any{}"},
+	}
+	for _, tt := range tests {
+		m, ok := byName[tt.name]
+		if !ok {
+			t.Errorf("no method named %s", tt.name)
+			continue
+		}
+		if m.Name != tt.name || m.Wire != tt.wire || m.Response != tt.response {
+			t.Errorf("expected %v %v %v, got %v %v %v", tt.name, tt.wire, tt.response, m.Name, m.Wire, m.Response)
+		}
+		if got := paramsExpr(m); got != tt.params {
+			t.Errorf("expected %v, got %v", tt.params, got)
+		}
+	}
+}
+
+func TestPrepareConflicts(t *testing.T) {
+	tests := [][]api.APIMethod{
+		{{API: "database_api", Method: "get_block"}, {API: "database_api", Method: "get_block"}},
+		{{API: "database_api", Method: "get_order_book"}, {API: "market_history_api", Method: "get_order_book"}},
+	}
+	for _, data := range tests {
+		if _, err := prepare(data); err == nil {
+			t.Errorf("expected an error for %v", data)
+		}
+	}
+}
+
+func TestParamName(t *testing.T) {
+	tests := map[string]string{
+		"bucket_seconds": "bucketSeconds",
+		"blockNum":       "blockNum",
+		"type":           "typeParam",
+		"err":            "errParam",
+	}
+	for in, want := range tests {
+		if got := paramName(in); got != want {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	}
+}
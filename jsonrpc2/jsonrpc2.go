@@ -19,6 +19,19 @@ type IJsonRpc interface {
 type JsonRpc struct {
 	Url      string
 	SendData []byte
+
+	// HTTPClient sends the requests. A client with a 30 second timeout is
+	// used when it is nil.
+	HTTPClient *http.Client
+}
+
+// Option configures a client created with NewClient.
+type Option func(*JsonRpc)
+
+// WithHTTPClient sets the HTTP client used to send requests, e.g. one whose
+// transport signs them with rpc.NewSigningTransport.
+func WithHTTPClient(client *http.Client) Option {
+	return func(j *JsonRpc) { j.HTTPClient = client }
 }
 
 func (j *JsonRpc) BuildSendData(method string, params []any) (err error) {
@@ -42,6 +55,22 @@ func (j *JsonRpc) BuildSendData(method string, params []any) (err error) {
 	return
 }
 
+// BuildObjectSendData builds a request whose params are an object, for
+// methods whose api.APIMethod.IsObject is set.
+func (j *JsonRpc) BuildObjectSendData(method string, params map[string]any) error {
+	tmp, err := json.Marshal(&api.RpcObjectSendData{
+		Id:      1,
+		JsonRpc: "2.0",
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+	j.SendData = tmp
+	return nil
+}
+
 func (j *JsonRpc) Send() (result *api.RpcResultData, err error) {
 	bodyReader := bytes.NewReader(j.SendData)
 	req, err := http.NewRequest(http.MethodPost, j.Url, bodyReader)
@@ -49,8 +78,11 @@ func (j *JsonRpc) Send() (result *api.RpcResultData, err error) {
 		return
 	}
 	req.Header.Set("Content-Type", "application/json")
-	client := http.Client{
-		Timeout: 30 * time.Second,
+	client := j.HTTPClient
+	if client == nil {
+		client = &http.Client{
+			Timeout: 30 * time.Second,
+		}
 	}
 	res, err := client.Do(req)
 	if err != nil {
@@ -65,9 +97,12 @@ func (j *JsonRpc) Send() (result *api.RpcResultData, err error) {
 	return
 }
 
-func NewClient(url string) *JsonRpc {
+func NewClient(url string, opts ...Option) *JsonRpc {
 	client := &JsonRpc{
 		Url: url,
 	}
+	for _, opt := range opts {
+		opt(client)
+	}
 	return client
 }
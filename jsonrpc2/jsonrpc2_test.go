@@ -20,6 +20,17 @@ func TestBuildSendData(t *testing.T) {
 	}
 }
 
+func TestBuildObjectSendData(t *testing.T) {
+	client := NewClient("https://api.steemit.com")
+	if err := client.BuildObjectSendData("rc_api.find_rc_accounts", map[string]any{"accounts": []string{"steemit"}}); err != nil {
+		t.Fatal(err)
+	}
+	expectedData := `{"id":1,"jsonrpc":"2.0","method":"rc_api.find_rc_accounts","params":{"accounts":["steemit"]}}`
+	if string(client.SendData) != expectedData {
+		t.Errorf("expected %v, got %v", expectedData, string(client.SendData))
+	}
+}
+
 func TestSend(t *testing.T) {
 	testData := `{"jsonrpc":"2.0","result":{"previous":"0000000000000000000000000000000000000000","timestamp":"2016-03-24T16:05:00","witness":"initminer","transaction_merkle_root":"0000000000000000000000000000000000000000","extensions":[],"witness_signature":"204f8ad56a8f5cf722a02b035a61b500aa59b9519b2c33c77a80c0a714680a5a5a7a340d909d19996613c5e4ae92146b9add8a7a663eef37d837ef881477313043","transactions":[],"block_id":"0000000109833ce528d5bbfb3f6225b39ee10086","signing_key":"STM8GC13uCZbP44HzMLV6zPZGwVQ8Nt4Kji8PapsPiNq1BK153XTX","transaction_ids":[]},"id":1}`
 	testDataBody := &api.RpcResultData{}
@@ -0,0 +1,27 @@
+package jsonrpc2
+
+//go:generate go run ./internal/apigen
+
+// call sends a request with positional params and decodes its result into v.
+func (j *JsonRpc) call(method string, params []any, v any) error {
+	if err := j.BuildSendData(method, params); err != nil {
+		return err
+	}
+	return j.send(v)
+}
+
+// callObject sends a request with object params and decodes its result into v.
+func (j *JsonRpc) callObject(method string, params map[string]any, v any) error {
+	if err := j.BuildObjectSendData(method, params); err != nil {
+		return err
+	}
+	return j.send(v)
+}
+
+func (j *JsonRpc) send(v any) error {
+	res, err := j.Send()
+	if err != nil {
+		return err
+	}
+	return res.DecodeResult(v)
+}
@@ -0,0 +1,653 @@
+// Code generated by apigen from api.MethodsData. DO NOT EDIT.
+
+package jsonrpc2
+
+import "github.com/steemit/steemutil/protocol/api"
+
+// SetSubscribeCallback calls condenser_api.set_subscribe_callback.
+func (j *JsonRpc) SetSubscribeCallback(callback, clearFilter any) (result api.SetSubscribeCallbackResponse, err error) {
+	err = j.call("condenser_api.set_subscribe_callback", []any{callback, clearFilter}, &result)
+	return
+}
+
+// SetPendingTransactionCallback calls condenser_api.set_pending_transaction_callback.
+func (j *JsonRpc) SetPendingTransactionCallback(cb any) (result api.SetPendingTransactionCallbackResponse, err error) {
+	err = j.call("condenser_api.set_pending_transaction_callback", []any{cb}, &result)
+	return
+}
+
+// SetBlockAppliedCallback calls condenser_api.set_block_applied_callback.
+func (j *JsonRpc) SetBlockAppliedCallback(cb any) (result api.SetBlockAppliedCallbackResponse, err error) {
+	err = j.call("condenser_api.set_block_applied_callback", []any{cb}, &result)
+	return
+}
+
+// CancelAllSubscriptions calls condenser_api.cancel_all_subscriptions.
+func (j *JsonRpc) CancelAllSubscriptions() (result api.CancelAllSubscriptionsResponse, err error) {
+	err = j.call("condenser_api.cancel_all_subscriptions", []any{}, &result)
+	return
+}
+
+// GetTrendingTags calls condenser_api.get_trending_tags.
+func (j *JsonRpc) GetTrendingTags(afterTag, limit any) (result api.GetTrendingTagsResponse, err error) {
+	err = j.call("condenser_api.get_trending_tags", []any{afterTag, limit}, &result)
+	return
+}
+
+// GetTagsUsedByAuthor calls condenser_api.get_tags_used_by_author.
+func (j *JsonRpc) GetTagsUsedByAuthor(author any) (result api.GetTagsUsedByAuthorResponse, err error) {
+	err = j.call("condenser_api.get_tags_used_by_author", []any{author}, &result)
+	return
+}
+
+// GetPostDiscussionsByPayout calls condenser_api.get_post_discussions_by_payout.
+func (j *JsonRpc) GetPostDiscussionsByPayout(query any) (result api.GetPostDiscussionsByPayoutResponse, err error) {
+	err = j.call("condenser_api.get_post_discussions_by_payout", []any{query}, &result)
+	return
+}
+
+// GetCommentDiscussionsByPayout calls condenser_api.get_comment_discussions_by_payout.
+func (j *JsonRpc) GetCommentDiscussionsByPayout(query any) (result api.GetCommentDiscussionsByPayoutResponse, err error) {
+	err = j.call("condenser_api.get_comment_discussions_by_payout", []any{query}, &result)
+	return
+}
+
+// GetDiscussionsByTrending calls condenser_api.get_discussions_by_trending.
+func (j *JsonRpc) GetDiscussionsByTrending(query any) (result api.GetDiscussionsByTrendingResponse, err error) {
+	err = j.call("condenser_api.get_discussions_by_trending", []any{query}, &result)
+	return
+}
+
+// GetDiscussionsByTrending30 calls condenser_api.get_discussions_by_trending30.
+func (j *JsonRpc) GetDiscussionsByTrending30(query any) (result api.GetDiscussionsByTrending30Response, err error) {
+	err = j.call("condenser_api.get_discussions_by_trending30", []any{query}, &result)
+	return
+}
+
+// GetDiscussionsByCreated calls condenser_api.get_discussions_by_created.
+func (j *JsonRpc) GetDiscussionsByCreated(query any) (result api.GetDiscussionsByCreatedResponse, err error) {
+	err = j.call("condenser_api.get_discussions_by_created", []any{query}, &result)
+	return
+}
+
+// GetDiscussionsByActive calls condenser_api.get_discussions_by_active.
+func (j *JsonRpc) GetDiscussionsByActive(query any) (result api.GetDiscussionsByActiveResponse, err error) {
+	err = j.call("condenser_api.get_discussions_by_active", []any{query}, &result)
+	return
+}
+
+// GetDiscussionsByCashout calls condenser_api.get_discussions_by_cashout.
+func (j *JsonRpc) GetDiscussionsByCashout(query any) (result api.GetDiscussionsByCashoutResponse, err error) {
+	err = j.call("condenser_api.get_discussions_by_cashout", []any{query}, &result)
+	return
+}
+
+// GetDiscussionsByPayout calls condenser_api.get_discussions_by_payout.
+func (j *JsonRpc) GetDiscussionsByPayout(query any) (result api.GetDiscussionsByPayoutResponse, err error) {
+	err = j.call("condenser_api.get_discussions_by_payout", []any{query}, &result)
+	return
+}
+
+// GetDiscussionsByVotes calls condenser_api.get_discussions_by_votes.
+func (j *JsonRpc) GetDiscussionsByVotes(query any) (result api.GetDiscussionsByVotesResponse, err error) {
+	err = j.call("condenser_api.get_discussions_by_votes", []any{query}, &result)
+	return
+}
+
+// GetDiscussionsByChildren calls condenser_api.get_discussions_by_children.
+func (j *JsonRpc) GetDiscussionsByChildren(query any) (result api.GetDiscussionsByChildrenResponse, err error) {
+	err = j.call("condenser_api.get_discussions_by_children", []any{query}, &result)
+	return
+}
+
+// GetDiscussionsByHot calls condenser_api.get_discussions_by_hot.
+func (j *JsonRpc) GetDiscussionsByHot(query any) (result api.GetDiscussionsByHotResponse, err error) {
+	err = j.call("condenser_api.get_discussions_by_hot", []any{query}, &result)
+	return
+}
+
+// GetDiscussionsByFeed calls condenser_api.get_discussions_by_feed.
+func (j *JsonRpc) GetDiscussionsByFeed(query any) (result api.GetDiscussionsByFeedResponse, err error) {
+	err = j.call("condenser_api.get_discussions_by_feed", []any{query}, &result)
+	return
+}
+
+// GetDiscussionsByBlog calls condenser_api.get_discussions_by_blog.
+func (j *JsonRpc) GetDiscussionsByBlog(query any) (result api.GetDiscussionsByBlogResponse, err error) {
+	err = j.call("condenser_api.get_discussions_by_blog", []any{query}, &result)
+	return
+}
+
+// GetDiscussionsByComments calls condenser_api.get_discussions_by_comments.
+func (j *JsonRpc) GetDiscussionsByComments(query any) (result api.GetDiscussionsByCommentsResponse, err error) {
+	err = j.call("condenser_api.get_discussions_by_comments", []any{query}, &result)
+	return
+}
+
+// GetDiscussionsByPromoted calls condenser_api.get_discussions_by_promoted.
+func (j *JsonRpc) GetDiscussionsByPromoted(query any) (result api.GetDiscussionsByPromotedResponse, err error) {
+	err = j.call("condenser_api.get_discussions_by_promoted", []any{query}, &result)
+	return
+}
+
+// GetBlockHeader calls condenser_api.get_block_header.
+func (j *JsonRpc) GetBlockHeader(blockNum any) (result api.GetBlockHeaderResponse, err error) {
+	err = j.call("condenser_api.get_block_header", []any{blockNum}, &result)
+	return
+}
+
+// GetBlock calls condenser_api.get_block.
+func (j *JsonRpc) GetBlock(blockNum any) (result api.GetBlockResponse, err error) {
+	err = j.call("condenser_api.get_block", []any{blockNum}, &result)
+	return
+}
+
+// GetOpsInBlock calls condenser_api.get_ops_in_block.
+func (j *JsonRpc) GetOpsInBlock(blockNum, onlyVirtual any) (result api.GetOpsInBlockResponse, err error) {
+	err = j.call("condenser_api.get_ops_in_block", []any{blockNum, onlyVirtual}, &result)
+	return
+}
+
+// GetState calls condenser_api.get_state.
+func (j *JsonRpc) GetState(path any) (result api.GetStateResponse, err error) {
+	err = j.call("condenser_api.get_state", []any{path}, &result)
+	return
+}
+
+// GetTrendingCategories calls condenser_api.get_trending_categories.
+func (j *JsonRpc) GetTrendingCategories(after, limit any) (result api.GetTrendingCategoriesResponse, err error) {
+	err = j.call("condenser_api.get_trending_categories", []any{after, limit}, &result)
+	return
+}
+
+// GetBestCategories calls condenser_api.get_best_categories.
+func (j *JsonRpc) GetBestCategories(after, limit any) (result api.GetBestCategoriesResponse, err error) {
+	err = j.call("condenser_api.get_best_categories", []any{after, limit}, &result)
+	return
+}
+
+// GetActiveCategories calls condenser_api.get_active_categories.
+func (j *JsonRpc) GetActiveCategories(after, limit any) (result api.GetActiveCategoriesResponse, err error) {
+	err = j.call("condenser_api.get_active_categories", []any{after, limit}, &result)
+	return
+}
+
+// GetRecentCategories calls condenser_api.get_recent_categories.
+func (j *JsonRpc) GetRecentCategories(after, limit any) (result api.GetRecentCategoriesResponse, err error) {
+	err = j.call("condenser_api.get_recent_categories", []any{after, limit}, &result)
+	return
+}
+
+// GetConfig calls condenser_api.get_config.
+func (j *JsonRpc) GetConfig() (result api.GetConfigResponse, err error) {
+	err = j.call("condenser_api.get_config", []any{}, &result)
+	return
+}
+
+// GetDynamicGlobalProperties calls condenser_api.get_dynamic_global_properties.
+func (j *JsonRpc) GetDynamicGlobalProperties() (result api.GetDynamicGlobalPropertiesResponse, err error) {
+	err = j.call("condenser_api.get_dynamic_global_properties", []any{}, &result)
+	return
+}
+
+// GetChainProperties calls condenser_api.get_chain_properties.
+func (j *JsonRpc) GetChainProperties() (result api.GetChainPropertiesResponse, err error) {
+	err = j.call("condenser_api.get_chain_properties", []any{}, &result)
+	return
+}
+
+// GetFeedHistory calls condenser_api.get_feed_history.
+func (j *JsonRpc) GetFeedHistory() (result api.GetFeedHistoryResponse, err error) {
+	err = j.call("condenser_api.get_feed_history", []any{}, &result)
+	return
+}
+
+// GetCurrentMedianHistoryPrice calls condenser_api.get_current_median_history_price.
+func (j *JsonRpc) GetCurrentMedianHistoryPrice() (result api.GetCurrentMedianHistoryPriceResponse, err error) {
+	err = j.call("condenser_api.get_current_median_history_price", []any{}, &result)
+	return
+}
+
+// GetWitnessSchedule calls condenser_api.get_witness_schedule.
+func (j *JsonRpc) GetWitnessSchedule() (result api.GetWitnessScheduleResponse, err error) {
+	err = j.call("condenser_api.get_witness_schedule", []any{}, &result)
+	return
+}
+
+// GetHardforkVersion calls condenser_api.get_hardfork_version.
+func (j *JsonRpc) GetHardforkVersion() (result api.GetHardforkVersionResponse, err error) {
+	err = j.call("condenser_api.get_hardfork_version", []any{}, &result)
+	return
+}
+
+// GetNextScheduledHardfork calls condenser_api.get_next_scheduled_hardfork.
+func (j *JsonRpc) GetNextScheduledHardfork() (result api.GetNextScheduledHardforkResponse, err error) {
+	err = j.call("condenser_api.get_next_scheduled_hardfork", []any{}, &result)
+	return
+}
+
+// GetKeyReferences calls condenser_api.get_key_references.
+func (j *JsonRpc) GetKeyReferences(key any) (result api.GetKeyReferencesResponse, err error) {
+	err = j.call("condenser_api.get_key_references", []any{key}, &result)
+	return
+}
+
+// GetAccounts calls condenser_api.get_accounts.
+func (j *JsonRpc) GetAccounts(names any) (result api.GetAccountsResponse, err error) {
+	err = j.call("condenser_api.get_accounts", []any{names}, &result)
+	return
+}
+
+// GetAccountReferences calls condenser_api.get_account_references.
+func (j *JsonRpc) GetAccountReferences(accountId any) (result api.GetAccountReferencesResponse, err error) {
+	err = j.call("condenser_api.get_account_references", []any{accountId}, &result)
+	return
+}
+
+// LookupAccountNames calls condenser_api.lookup_account_names.
+func (j *JsonRpc) LookupAccountNames(accountNames any) (result api.LookupAccountNamesResponse, err error) {
+	err = j.call("condenser_api.lookup_account_names", []any{accountNames}, &result)
+	return
+}
+
+// LookupAccounts calls condenser_api.lookup_accounts.
+func (j *JsonRpc) LookupAccounts(lowerBoundName, limit any) (result api.LookupAccountsResponse, err error) {
+	err = j.call("condenser_api.lookup_accounts", []any{lowerBoundName, limit}, &result)
+	return
+}
+
+// GetAccountCount calls condenser_api.get_account_count.
+func (j *JsonRpc) GetAccountCount() (result api.GetAccountCountResponse, err error) {
+	err = j.call("condenser_api.get_account_count", []any{}, &result)
+	return
+}
+
+// GetConversionRequests calls condenser_api.get_conversion_requests.
+func (j *JsonRpc) GetConversionRequests(accountName any) (result api.GetConversionRequestsResponse, err error) {
+	err = j.call("condenser_api.get_conversion_requests", []any{accountName}, &result)
+	return
+}
+
+// GetAccountHistory calls condenser_api.get_account_history.
+func (j *JsonRpc) GetAccountHistory(account, from, limit any) (result api.GetAccountHistoryResponse, err error) {
+	err = j.call("condenser_api.get_account_history", []any{account, from, limit}, &result)
+	return
+}
+
+// GetOwnerHistory calls condenser_api.get_owner_history.
+func (j *JsonRpc) GetOwnerHistory(account any) (result api.GetOwnerHistoryResponse, err error) {
+	err = j.call("condenser_api.get_owner_history", []any{account}, &result)
+	return
+}
+
+// GetRecoveryRequest calls condenser_api.get_recovery_request.
+func (j *JsonRpc) GetRecoveryRequest(account any) (result api.GetRecoveryRequestResponse, err error) {
+	err = j.call("condenser_api.get_recovery_request", []any{account}, &result)
+	return
+}
+
+// GetEscrow calls condenser_api.get_escrow.
+func (j *JsonRpc) GetEscrow(from, escrowId any) (result api.GetEscrowResponse, err error) {
+	err = j.call("condenser_api.get_escrow", []any{from, escrowId}, &result)
+	return
+}
+
+// GetWithdrawRoutes calls condenser_api.get_withdraw_routes.
+func (j *JsonRpc) GetWithdrawRoutes(account, withdrawRouteType any) (result api.GetWithdrawRoutesResponse, err error) {
+	err = j.call("condenser_api.get_withdraw_routes", []any{account, withdrawRouteType}, &result)
+	return
+}
+
+// GetAccountBandwidth calls condenser_api.get_account_bandwidth.
+func (j *JsonRpc) GetAccountBandwidth(account, bandwidthType any) (result api.GetAccountBandwidthResponse, err error) {
+	err = j.call("condenser_api.get_account_bandwidth", []any{account, bandwidthType}, &result)
+	return
+}
+
+// GetSavingsWithdrawFrom calls condenser_api.get_savings_withdraw_from.
+func (j *JsonRpc) GetSavingsWithdrawFrom(account any) (result api.GetSavingsWithdrawFromResponse, err error) {
+	err = j.call("condenser_api.get_savings_withdraw_from", []any{account}, &result)
+	return
+}
+
+// GetSavingsWithdrawTo calls condenser_api.get_savings_withdraw_to.
+func (j *JsonRpc) GetSavingsWithdrawTo(account any) (result api.GetSavingsWithdrawToResponse, err error) {
+	err = j.call("condenser_api.get_savings_withdraw_to", []any{account}, &result)
+	return
+}
+
+// GetOrderBook calls condenser_api.get_order_book.
+func (j *JsonRpc) GetOrderBook(limit any) (result api.GetOrderBookResponse, err error) {
+	err = j.call("condenser_api.get_order_book", []any{limit}, &result)
+	return
+}
+
+// GetOpenOrders calls condenser_api.get_open_orders.
+func (j *JsonRpc) GetOpenOrders(owner any) (result api.GetOpenOrdersResponse, err error) {
+	err = j.call("condenser_api.get_open_orders", []any{owner}, &result)
+	return
+}
+
+// GetLiquidityQueue calls condenser_api.get_liquidity_queue.
+func (j *JsonRpc) GetLiquidityQueue(startAccount, limit any) (result api.GetLiquidityQueueResponse, err error) {
+	err = j.call("condenser_api.get_liquidity_queue", []any{startAccount, limit}, &result)
+	return
+}
+
+// GetTransactionHex calls condenser_api.get_transaction_hex.
+func (j *JsonRpc) GetTransactionHex(trx any) (result api.GetTransactionHexResponse, err error) {
+	err = j.call("condenser_api.get_transaction_hex", []any{trx}, &result)
+	return
+}
+
+// GetTransaction calls condenser_api.get_transaction.
+func (j *JsonRpc) GetTransaction(trxId any) (result api.GetTransactionResponse, err error) {
+	err = j.call("condenser_api.get_transaction", []any{trxId}, &result)
+	return
+}
+
+// GetRequiredSignatures calls condenser_api.get_required_signatures.
+func (j *JsonRpc) GetRequiredSignatures(trx, availableKeys any) (result api.GetRequiredSignaturesResponse, err error) {
+	err = j.call("condenser_api.get_required_signatures", []any{trx, availableKeys}, &result)
+	return
+}
+
+// GetPotentialSignatures calls condenser_api.get_potential_signatures.
+func (j *JsonRpc) GetPotentialSignatures(trx any) (result api.GetPotentialSignaturesResponse, err error) {
+	err = j.call("condenser_api.get_potential_signatures", []any{trx}, &result)
+	return
+}
+
+// VerifyAuthority calls condenser_api.verify_authority.
+func (j *JsonRpc) VerifyAuthority(trx any) (result api.VerifyAuthorityResponse, err error) {
+	err = j.call("condenser_api.verify_authority", []any{trx}, &result)
+	return
+}
+
+// VerifyAccountAuthority calls condenser_api.verify_account_authority.
+func (j *JsonRpc) VerifyAccountAuthority(nameOrId, signers any) (result api.VerifyAccountAuthorityResponse, err error) {
+	err = j.call("condenser_api.verify_account_authority", []any{nameOrId, signers}, &result)
+	return
+}
+
+// GetActiveVotes calls condenser_api.get_active_votes.
+func (j *JsonRpc) GetActiveVotes(author, permlink any) (result api.GetActiveVotesResponse, err error) {
+	err = j.call("condenser_api.get_active_votes", []any{author, permlink}, &result)
+	return
+}
+
+// GetAccountVotes calls condenser_api.get_account_votes.
+func (j *JsonRpc) GetAccountVotes(voter any) (result api.GetAccountVotesResponse, err error) {
+	err = j.call("condenser_api.get_account_votes", []any{voter}, &result)
+	return
+}
+
+// GetContent calls condenser_api.get_content.
+func (j *JsonRpc) GetContent(author, permlink any) (result api.GetContentResponse, err error) {
+	err = j.call("condenser_api.get_content", []any{author, permlink}, &result)
+	return
+}
+
+// GetContentReplies calls condenser_api.get_content_replies.
+func (j *JsonRpc) GetContentReplies(author, permlink any) (result api.GetContentRepliesResponse, err error) {
+	err = j.call("condenser_api.get_content_replies", []any{author, permlink}, &result)
+	return
+}
+
+// GetDiscussionsByAuthorBeforeDate calls condenser_api.get_discussions_by_author_before_date.
+func (j *JsonRpc) GetDiscussionsByAuthorBeforeDate(author, startPermlink, beforeDate, limit any) (result api.GetDiscussionsByAuthorBeforeDateResponse, err error) {
+	err = j.call("condenser_api.get_discussions_by_author_before_date", []any{author, startPermlink, beforeDate, limit}, &result)
+	return
+}
+
+// GetRepliesByLastUpdate calls condenser_api.get_replies_by_last_update.
+func (j *JsonRpc) GetRepliesByLastUpdate(startAuthor, startPermlink, limit any) (result api.GetRepliesByLastUpdateResponse, err error) {
+	err = j.call("condenser_api.get_replies_by_last_update", []any{startAuthor, startPermlink, limit}, &result)
+	return
+}
+
+// GetWitnesses calls condenser_api.get_witnesses.
+func (j *JsonRpc) GetWitnesses(witnessIds any) (result api.GetWitnessesResponse, err error) {
+	err = j.call("condenser_api.get_witnesses", []any{witnessIds}, &result)
+	return
+}
+
+// GetWitnessByAccount calls condenser_api.get_witness_by_account.
+func (j *JsonRpc) GetWitnessByAccount(accountName any) (result api.GetWitnessByAccountResponse, err error) {
+	err = j.call("condenser_api.get_witness_by_account", []any{accountName}, &result)
+	return
+}
+
+// GetWitnessesByVote calls condenser_api.get_witnesses_by_vote.
+func (j *JsonRpc) GetWitnessesByVote(from, limit any) (result api.GetWitnessesByVoteResponse, err error) {
+	err = j.call("condenser_api.get_witnesses_by_vote", []any{from, limit}, &result)
+	return
+}
+
+// LookupWitnessAccounts calls condenser_api.lookup_witness_accounts.
+func (j *JsonRpc) LookupWitnessAccounts(lowerBoundName, limit any) (result api.LookupWitnessAccountsResponse, err error) {
+	err = j.call("condenser_api.lookup_witness_accounts", []any{lowerBoundName, limit}, &result)
+	return
+}
+
+// GetWitnessCount calls condenser_api.get_witness_count.
+func (j *JsonRpc) GetWitnessCount() (result api.GetWitnessCountResponse, err error) {
+	err = j.call("condenser_api.get_witness_count", []any{}, &result)
+	return
+}
+
+// GetActiveWitnesses calls condenser_api.get_active_witnesses.
+func (j *JsonRpc) GetActiveWitnesses() (result api.GetActiveWitnessesResponse, err error) {
+	err = j.call("condenser_api.get_active_witnesses", []any{}, &result)
+	return
+}
+
+// GetMinerQueue calls condenser_api.get_miner_queue.
+func (j *JsonRpc) GetMinerQueue() (result api.GetMinerQueueResponse, err error) {
+	err = j.call("condenser_api.get_miner_queue", []any{}, &result)
+	return
+}
+
+// GetRewardFund calls condenser_api.get_reward_fund.
+func (j *JsonRpc) GetRewardFund(name any) (result api.GetRewardFundResponse, err error) {
+	err = j.call("condenser_api.get_reward_fund", []any{name}, &result)
+	return
+}
+
+// GetVestingDelegations calls condenser_api.get_vesting_delegations.
+func (j *JsonRpc) GetVestingDelegations(account, from, limit any) (result api.GetVestingDelegationsResponse, err error) {
+	err = j.call("condenser_api.get_vesting_delegations", []any{account, from, limit}, &result)
+	return
+}
+
+// Login calls condenser_api.login.
+func (j *JsonRpc) Login(username, password any) (result api.LoginResponse, err error) {
+	err = j.call("condenser_api.login", []any{username, password}, &result)
+	return
+}
+
+// GetApiByName calls condenser_api.get_api_by_name.
+func (j *JsonRpc) GetApiByName(databaseApi any) (result api.GetApiByNameResponse, err error) {
+	err = j.call("condenser_api.get_api_by_name", []any{databaseApi}, &result)
+	return
+}
+
+// GetVersion calls condenser_api.get_version.
+func (j *JsonRpc) GetVersion() (result api.GetVersionResponse, err error) {
+	err = j.call("condenser_api.get_version", []any{}, &result)
+	return
+}
+
+// GetFollowers calls condenser_api.get_followers.
+func (j *JsonRpc) GetFollowers(following, startFollower, followType, limit any) (result api.GetFollowersResponse, err error) {
+	err = j.call("condenser_api.get_followers", []any{following, startFollower, followType, limit}, &result)
+	return
+}
+
+// GetFollowing calls condenser_api.get_following.
+func (j *JsonRpc) GetFollowing(follower, startFollowing, followType, limit any) (result api.GetFollowingResponse, err error) {
+	err = j.call("condenser_api.get_following", []any{follower, startFollowing, followType, limit}, &result)
+	return
+}
+
+// GetFollowCount calls condenser_api.get_follow_count.
+func (j *JsonRpc) GetFollowCount(account any) (result api.GetFollowCountResponse, err error) {
+	err = j.call("condenser_api.get_follow_count", []any{account}, &result)
+	return
+}
+
+// GetFeedEntries calls condenser_api.get_feed_entries.
+func (j *JsonRpc) GetFeedEntries(account, entryId, limit any) (result api.GetFeedEntriesResponse, err error) {
+	err = j.call("condenser_api.get_feed_entries", []any{account, entryId, limit}, &result)
+	return
+}
+
+// GetFeed calls condenser_api.get_feed.
+func (j *JsonRpc) GetFeed(account, entryId, limit any) (result api.GetFeedResponse, err error) {
+	err = j.call("condenser_api.get_feed", []any{account, entryId, limit}, &result)
+	return
+}
+
+// GetBlogEntries calls condenser_api.get_blog_entries.
+func (j *JsonRpc) GetBlogEntries(account, entryId, limit any) (result api.GetBlogEntriesResponse, err error) {
+	err = j.call("condenser_api.get_blog_entries", []any{account, entryId, limit}, &result)
+	return
+}
+
+// GetBlog calls condenser_api.get_blog.
+func (j *JsonRpc) GetBlog(account, entryId, limit any) (result api.GetBlogResponse, err error) {
+	err = j.call("condenser_api.get_blog", []any{account, entryId, limit}, &result)
+	return
+}
+
+// GetAccountReputations calls condenser_api.get_account_reputations.
+func (j *JsonRpc) GetAccountReputations(lowerBoundName, limit any) (result api.GetAccountReputationsResponse, err error) {
+	err = j.call("condenser_api.get_account_reputations", []any{lowerBoundName, limit}, &result)
+	return
+}
+
+// GetRebloggedBy calls condenser_api.get_reblogged_by.
+func (j *JsonRpc) GetRebloggedBy(author, permlink any) (result api.GetRebloggedByResponse, err error) {
+	err = j.call("condenser_api.get_reblogged_by", []any{author, permlink}, &result)
+	return
+}
+
+// GetBlogAuthors calls condenser_api.get_blog_authors.
+func (j *JsonRpc) GetBlogAuthors(blogAccount any) (result api.GetBlogAuthorsResponse, err error) {
+	err = j.call("condenser_api.get_blog_authors", []any{blogAccount}, &result)
+	return
+}
+
+// BroadcastTransaction calls condenser_api.broadcast_transaction.
+func (j *JsonRpc) BroadcastTransaction(trx any) (result api.BroadcastTransactionResponse, err error) {
+	err = j.call("condenser_api.broadcast_transaction", []any{trx}, &result)
+	return
+}
+
+// BroadcastTransactionWithCallback calls condenser_api.broadcast_transaction_with_callback.
+func (j *JsonRpc) BroadcastTransactionWithCallback(confirmationCallback, trx any) (result api.BroadcastTransactionWithCallbackResponse, err error) {
+	err = j.call("condenser_api.broadcast_transaction_with_callback", []any{confirmationCallback, trx}, &result)
+	return
+}
+
+// BroadcastTransactionSynchronous calls condenser_api.broadcast_transaction_synchronous.
+func (j *JsonRpc) BroadcastTransactionSynchronous(trx any) (result api.BroadcastTransactionSynchronousResponse, err error) {
+	err = j.call("condenser_api.broadcast_transaction_synchronous", []any{trx}, &result)
+	return
+}
+
+// BroadcastBlock calls condenser_api.broadcast_block.
+func (j *JsonRpc) BroadcastBlock(b any) (result api.BroadcastBlockResponse, err error) {
+	err = j.call("condenser_api.broadcast_block", []any{b}, &result)
+	return
+}
+
+// SetMaxBlockAge calls condenser_api.set_max_block_age.
+func (j *JsonRpc) SetMaxBlockAge(maxBlockAge any) (result api.SetMaxBlockAgeResponse, err error) {
+	err = j.call("condenser_api.set_max_block_age", []any{maxBlockAge}, &result)
+	return
+}
+
+// GetTicker calls condenser_api.get_ticker.
+func (j *JsonRpc) GetTicker() (result api.GetTickerResponse, err error) {
+	err = j.call("condenser_api.get_ticker", []any{}, &result)
+	return
+}
+
+// GetVolume calls condenser_api.get_volume.
+func (j *JsonRpc) GetVolume() (result api.GetVolumeResponse, err error) {
+	err = j.call("condenser_api.get_volume", []any{}, &result)
+	return
+}
+
+// GetMarketOrderBook calls condenser_api.get_order_book.
+func (j *JsonRpc) GetMarketOrderBook(limit any) (result api.GetMarketOrderBookResponse, err error) {
+	err = j.call("condenser_api.get_order_book", []any{limit}, &result)
+	return
+}
+
+// GetTradeHistory calls condenser_api.get_trade_history.
+func (j *JsonRpc) GetTradeHistory(start, end, limit any) (result api.GetTradeHistoryResponse, err error) {
+	err = j.call("condenser_api.get_trade_history", []any{start, end, limit}, &result)
+	return
+}
+
+// GetRecentTrades calls condenser_api.get_recent_trades.
+func (j *JsonRpc) GetRecentTrades(limit any) (result api.GetRecentTradesResponse, err error) {
+	err = j.call("condenser_api.get_recent_trades", []any{limit}, &result)
+	return
+}
+
+// GetMarketHistory calls condenser_api.get_market_history.
+func (j *JsonRpc) GetMarketHistory(bucketSeconds, start, end any) (result api.GetMarketHistoryResponse, err error) {
+	err = j.call("condenser_api.get_market_history", []any{bucketSeconds, start, end}, &result)
+	return
+}
+
+// GetMarketHistoryBuckets calls condenser_api.get_market_history_buckets.
+func (j *JsonRpc) GetMarketHistoryBuckets() (result api.GetMarketHistoryBucketsResponse, err error) {
+	err = j.call("condenser_api.get_market_history_buckets", []any{}, &result)
+	return
+}
+
+// FindProposals calls condenser_api.find_proposals.
+func (j *JsonRpc) FindProposals(idSet any) (result api.FindProposalsResponse, err error) {
+	err = j.call("condenser_api.find_proposals", []any{idSet}, &result)
+	return
+}
+
+// ListProposals calls condenser_api.list_proposals.
+func (j *JsonRpc) ListProposals(start, limit, orderBy, orderDirection, status any) (result api.ListProposalsResponse, err error) {
+	err = j.call("condenser_api.list_proposals", []any{start, limit, orderBy, orderDirection, status}, &result)
+	return
+}
+
+// ListProposalVotes calls condenser_api.list_proposal_votes.
+func (j *JsonRpc) ListProposalVotes(start, limit, orderBy, orderDirection, status any) (result api.ListProposalVotesResponse, err error) {
+	err = j.call("condenser_api.list_proposal_votes", []any{start, limit, orderBy, orderDirection, status}, &result)
+	return
+}
+
+// GetNaiPool calls condenser_api.get_nai_pool.
+func (j *JsonRpc) GetNaiPool() (result api.GetNaiPoolResponse, err error) {
+	err = j.call("condenser_api.get_nai_pool", []any{}, &result)
+	return
+}
+
+// FindRcAccounts calls rc_api.find_rc_accounts.
+func (j *JsonRpc) FindRcAccounts(accounts any) (result api.FindRcAccountsResponse, err error) {
+	err = j.callObject("rc_api.find_rc_accounts", map[string]any{"accounts": accounts}, &result)
+	return
+}
+
+// GetExpiringVestingDelegations calls condenser_api.get_expiring_vesting_delegations.
+func (j *JsonRpc) GetExpiringVestingDelegations(account, start, limit any) (result api.GetExpiringVestingDelegationsResponse, err error) {
+	err = j.call("condenser_api.get_expiring_vesting_delegations", []any{account, start, limit}, &result)
+	return
+}
+
+// FindChangeRecoveryAccountRequests calls database_api.find_change_recovery_account_requests.
+func (j *JsonRpc) FindChangeRecoveryAccountRequests(account any) (result api.FindChangeRecoveryAccountRequestsResponse, err error) {
+	err = j.callObject("database_api.find_change_recovery_account_requests", map[string]any{"account": account}, &result)
+	return
+}
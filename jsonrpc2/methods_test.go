@@ -0,0 +1,76 @@
+package jsonrpc2
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// mockMethod responds to a method with result and records the raw params.
+func mockMethod(t *testing.T, method string, result string, params *json.RawMessage) {
+	httpmock.RegisterResponder("POST", "https://api.steemit.com",
+		func(req *http.Request) (*http.Response, error) {
+			var tmpReq struct {
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&tmpReq); err != nil {
+				return nil, err
+			}
+			if tmpReq.Method != method {
+				t.Errorf("expected %v, got %v", method, tmpReq.Method)
+			}
+			*params = tmpReq.Params
+			return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0","id":1,"result":`+result+`}`), nil
+		},
+	)
+}
+
+func TestGeneratedPositionalMethod(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	var params json.RawMessage
+	mockMethod(t, "condenser_api.get_accounts", `[{"name":"steemit","balance":{"amount":"1000","precision":3,"nai":"@@000000021"}}]`, &params)
+
+	accounts, err := NewClient("https://api.steemit.com").GetAccounts([]string{"steemit"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(params) != `[["steemit"]]` {
+		t.Errorf("expected %v, got %v", `[["steemit"]]`, string(params))
+	}
+	if len(accounts) != 1 || accounts[0].Balance != "1.000 STEEM" {
+		t.Errorf("unexpected accounts %+v", accounts)
+	}
+}
+
+func TestGeneratedObjectMethod(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	var params json.RawMessage
+	mockMethod(t, "rc_api.find_rc_accounts", `{"rc_accounts":[{"account":"steemit","max_rc":"1000"}]}`, &params)
+
+	res, err := NewClient("https://api.steemit.com").FindRcAccounts([]string{"steemit"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(params) != `{"accounts":["steemit"]}` {
+		t.Errorf("expected %v, got %v", `{"accounts":["steemit"]}`, string(params))
+	}
+	if len(res.RCAccounts) != 1 || res.RCAccounts[0].MaxRC != 1000 {
+		t.Errorf("unexpected rc accounts %+v", res)
+	}
+}
+
+func TestGeneratedMethodError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", "https://api.steemit.com",
+		httpmock.NewStringResponder(200, `{"jsonrpc":"2.0","id":1,"error":{"code":-32003,"message":"Unable to acquire database lock"}}`))
+
+	if _, err := NewClient("https://api.steemit.com").GetDynamicGlobalProperties(); err == nil {
+		t.Error("expected an error for an rpc error response")
+	}
+}
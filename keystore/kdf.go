@@ -0,0 +1,108 @@
+package keystore
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Supported key derivation functions.
+const (
+	KDFScrypt   = "scrypt"
+	KDFArgon2id = "argon2id"
+)
+
+const keyLen = 32
+
+// Bounds on the KDF parameters read from a keystore file, so that a crafted
+// file cannot make Unlock use unbounded memory or time. Both defaults are
+// well within them.
+const (
+	maxScryptN      = 1 << 20
+	maxScryptR      = 32
+	maxScryptP      = 16
+	maxKDFMemory    = 1 << 30 // bytes
+	maxArgon2Time   = 64
+	maxArgon2Thread = 64
+)
+
+// KDFParams describes how the encryption key is derived from the passphrase.
+// N, R and P apply to scrypt; Time, Memory (in KiB) and Threads to argon2id.
+type KDFParams struct {
+	Name string `json:"name"`
+	Salt []byte `json:"salt"`
+
+	N int `json:"n,omitempty"`
+	R int `json:"r,omitempty"`
+	P int `json:"p,omitempty"`
+
+	Time    uint32 `json:"time,omitempty"`
+	Memory  uint32 `json:"memory,omitempty"`
+	Threads uint8  `json:"threads,omitempty"`
+}
+
+// DefaultScryptParams returns the scrypt parameters used for new keystores.
+func DefaultScryptParams() KDFParams {
+	return KDFParams{Name: KDFScrypt, N: 1 << 15, R: 8, P: 1}
+}
+
+// DefaultArgon2idParams returns the argon2id parameters recommended by
+// RFC 9106 for memory constrained environments.
+func DefaultArgon2idParams() KDFParams {
+	return KDFParams{Name: KDFArgon2id, Time: 3, Memory: 64 * 1024, Threads: 4}
+}
+
+// validate checks the parameters are supported and within the bounds above.
+func (p *KDFParams) validate() error {
+	switch p.Name {
+	case KDFScrypt:
+		if p.N < 2 || p.N&(p.N-1) != 0 || p.N > maxScryptN {
+			return errors.Errorf("invalid scrypt N: %d", p.N)
+		}
+		if p.R < 1 || p.R > maxScryptR {
+			return errors.Errorf("invalid scrypt r: %d", p.R)
+		}
+		if p.P < 1 || p.P > maxScryptP {
+			return errors.Errorf("invalid scrypt p: %d", p.P)
+		}
+		if 128*int64(p.N)*int64(p.R) > maxKDFMemory {
+			return errors.New("scrypt parameters need too much memory")
+		}
+	case KDFArgon2id:
+		if p.Time == 0 || p.Time > maxArgon2Time {
+			return errors.Errorf("invalid argon2id time: %d", p.Time)
+		}
+		if p.Memory == 0 || int64(p.Memory)*1024 > maxKDFMemory {
+			return errors.Errorf("invalid argon2id memory: %d", p.Memory)
+		}
+		if p.Threads == 0 || p.Threads > maxArgon2Thread {
+			return errors.Errorf("invalid argon2id threads: %d", p.Threads)
+		}
+	default:
+		return errors.Errorf("unsupported KDF: %s", p.Name)
+	}
+	return nil
+}
+
+func (p *KDFParams) deriveKey(passphrase []byte) ([]byte, error) {
+	if len(p.Salt) == 0 {
+		return nil, errors.New("missing KDF salt")
+	}
+
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	switch p.Name {
+	case KDFScrypt:
+		key, err := scrypt.Key(passphrase, p.Salt, p.N, p.R, p.P, keyLen)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to derive scrypt key")
+		}
+		return key, nil
+	case KDFArgon2id:
+		return argon2.IDKey(passphrase, p.Salt, p.Time, p.Memory, p.Threads, keyLen), nil
+	default:
+		return nil, errors.Errorf("unsupported KDF: %s", p.Name)
+	}
+}
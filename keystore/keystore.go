@@ -0,0 +1,508 @@
+// Package keystore stores private keys in a passphrase encrypted file.
+//
+// Keys are indexed by account and role. The file is encrypted with
+// AES-256-GCM under a key derived from the passphrase with scrypt or
+// argon2id. Keys are only held in memory while the store is unlocked and
+// are zeroed when it is locked again; signing goes through the store so the
+// raw keys never have to leave it.
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	secp256k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/signer"
+	"github.com/steemit/steemutil/transaction"
+	"github.com/steemit/steemutil/wif"
+)
+
+const fileVersion = 1
+
+var (
+	// ErrLocked is returned by operations that need the store to be unlocked.
+	ErrLocked = errors.New("keystore is locked")
+
+	// ErrKeyNotFound is returned when no key is stored for an account and role.
+	ErrKeyNotFound = errors.New("key not found")
+
+	// ErrInvalidPassphrase is returned when the file cannot be decrypted.
+	ErrInvalidPassphrase = errors.New("invalid passphrase")
+)
+
+// KeyInfo describes a stored key without exposing it.
+type KeyInfo struct {
+	Account   string `json:"account"`
+	Role      string `json:"role"`
+	PublicKey string `json:"public_key"`
+}
+
+type keyFile struct {
+	Version    int       `json:"version"`
+	KDF        KDFParams `json:"kdf"`
+	Cipher     string    `json:"cipher"`
+	Nonce      []byte    `json:"nonce"`
+	Ciphertext []byte    `json:"ciphertext"`
+}
+
+type entry struct {
+	Account string `json:"account"`
+	Role    string `json:"role"`
+	Key     []byte `json:"key"`
+}
+
+type entryID struct {
+	account string
+	role    string
+}
+
+// Store is an encrypted keystore backed by a file.
+type Store struct {
+	path string
+
+	mu      sync.Mutex
+	file    keyFile
+	key     []byte
+	entries map[entryID][]byte
+	timer   *time.Timer
+
+	// session is incremented by every lock, so that the timer of an earlier
+	// session that fires late cannot lock a later one.
+	session uint64
+}
+
+// Create creates a new, empty keystore file protected by the passphrase. If
+// kdf is nil, DefaultScryptParams is used. The returned store is unlocked
+// until Lock is called.
+func Create(path, passphrase string, kdf *KDFParams) (*Store, error) {
+	if _, err := os.Stat(path); err == nil {
+		return nil, errors.Errorf("keystore already exists: %s", path)
+	}
+
+	params := DefaultScryptParams()
+	if kdf != nil {
+		params = *kdf
+	}
+	params.Salt = make([]byte, 32)
+	if _, err := rand.Read(params.Salt); err != nil {
+		return nil, errors.Wrap(err, "failed to generate salt")
+	}
+
+	key, err := params.deriveKey([]byte(passphrase))
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Store{
+		path:    path,
+		file:    keyFile{Version: fileVersion, KDF: params, Cipher: "aes-256-gcm"},
+		key:     key,
+		entries: make(map[entryID][]byte),
+	}
+	if err := s.save(); err != nil {
+		s.Lock()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Open opens an existing keystore file. The store is locked.
+func Open(path string) (*Store, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read keystore")
+	}
+
+	var file keyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, errors.Wrap(err, "failed to decode keystore")
+	}
+	if file.Version != fileVersion {
+		return nil, errors.Errorf("unsupported keystore version: %d", file.Version)
+	}
+	if file.Cipher != "aes-256-gcm" {
+		return nil, errors.Errorf("unsupported keystore cipher: %s", file.Cipher)
+	}
+	if err := file.KDF.validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid keystore KDF")
+	}
+
+	return &Store{path: path, file: file}, nil
+}
+
+// Unlock decrypts the keystore. If timeout is positive, the store locks
+// itself again once it elapses; unlocking an unlocked store resets the timer.
+func (s *Store) Unlock(passphrase string, timeout time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, err := s.file.KDF.deriveKey([]byte(passphrase))
+	if err != nil {
+		return err
+	}
+
+	entries, err := s.decrypt(key)
+	if err != nil {
+		zero(key)
+		return err
+	}
+
+	s.lock()
+	s.key = key
+	s.entries = entries
+	if timeout > 0 {
+		session := s.session
+		s.timer = time.AfterFunc(timeout, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.session == session {
+				s.lock()
+			}
+		})
+	}
+	return nil
+}
+
+// Lock zeroes the decrypted keys and the derived encryption key.
+func (s *Store) Lock() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lock()
+}
+
+// IsLocked reports whether the store is locked.
+func (s *Store) IsLocked() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entries == nil
+}
+
+// Import stores a private key for the account and role, replacing any key
+// previously stored for them, and writes the keystore file.
+func (s *Store) Import(account, role string, privKey *wif.PrivateKey) error {
+	if account == "" || role == "" {
+		return errors.New("account and role are required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entries == nil {
+		return ErrLocked
+	}
+
+	id := entryID{account, role}
+	old := s.entries[id]
+	s.entries[id] = privKey.ToByte()
+	if err := s.save(); err != nil {
+		if old == nil {
+			delete(s.entries, id)
+		} else {
+			s.entries[id] = old
+		}
+		return err
+	}
+	zero(old)
+	return nil
+}
+
+// ImportWif stores a private key given in WIF format.
+func (s *Store) ImportWif(account, role, privWif string) error {
+	privKey := &wif.PrivateKey{}
+	if err := privKey.FromWif(privWif); err != nil {
+		return err
+	}
+	return s.Import(account, role, privKey)
+}
+
+// Remove deletes the key of the account and role and writes the keystore file.
+func (s *Store) Remove(account, role string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entries == nil {
+		return ErrLocked
+	}
+
+	id := entryID{account, role}
+	raw, ok := s.entries[id]
+	if !ok {
+		return ErrKeyNotFound
+	}
+
+	delete(s.entries, id)
+	if err := s.save(); err != nil {
+		s.entries[id] = raw
+		return err
+	}
+	zero(raw)
+	return nil
+}
+
+// Keys lists the stored keys, sorted by account and role.
+func (s *Store) Keys() ([]KeyInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entries == nil {
+		return nil, ErrLocked
+	}
+
+	keys := make([]KeyInfo, 0, len(s.entries))
+	for id, raw := range s.entries {
+		keys = append(keys, KeyInfo{
+			Account:   id.account,
+			Role:      id.role,
+			PublicKey: publicKey(raw).ToStr(),
+		})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Account != keys[j].Account {
+			return keys[i].Account < keys[j].Account
+		}
+		return keys[i].Role < keys[j].Role
+	})
+	return keys, nil
+}
+
+// PublicKey returns the public key stored for the account and role.
+func (s *Store) PublicKey(account, role string) (*wif.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	raw, err := s.get(account, role)
+	if err != nil {
+		return nil, err
+	}
+	return publicKey(raw), nil
+}
+
+// SignDigest signs a 32-byte digest with the key of the account and role and
+// returns the 65-byte compact signature.
+func (s *Store) SignDigest(account, role string, digest []byte) ([]byte, error) {
+	if len(digest) != 32 {
+		return nil, errors.Errorf("digest must be 32 bytes, got %d", len(digest))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	raw, err := s.get(account, role)
+	if err != nil {
+		return nil, err
+	}
+
+	privKey := secp256k1.PrivKeyFromBytes(raw)
+	defer privKey.Zero()
+
+	sig, err := ecdsa.SignCompact(privKey, digest, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign digest")
+	}
+	return sig, nil
+}
+
+// SharedSecret returns the SHA-512 hashed ECDH shared secret between the key
+// of the account and role and the given public key.
+func (s *Store) SharedSecret(account, role string, pub *wif.PublicKey) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	raw, err := s.get(account, role)
+	if err != nil {
+		return nil, err
+	}
+
+	privKey := secp256k1.PrivKeyFromBytes(raw)
+	defer privKey.Zero()
+
+	x := secp256k1.GenerateSharedSecret(privKey, pub.Raw)
+	defer zero(x)
+	secret := sha512.Sum512(x)
+	return secret[:], nil
+}
+
+// Signer returns a signer for the key of the account and role. The signer
+// keeps working across lock and unlock cycles and fails while the store is
+// locked.
+func (s *Store) Signer(account, role string) (signer.SharedSecretSigner, error) {
+	pub, err := s.PublicKey(account, role)
+	if err != nil {
+		return nil, err
+	}
+	return &storeSigner{store: s, account: account, role: role, pub: pub}, nil
+}
+
+type storeSigner struct {
+	store   *Store
+	account string
+	role    string
+	pub     *wif.PublicKey
+}
+
+func (ss *storeSigner) PublicKey() *wif.PublicKey {
+	return ss.pub
+}
+
+func (ss *storeSigner) SignDigest(digest []byte) ([]byte, error) {
+	return ss.store.SignDigest(ss.account, ss.role, digest)
+}
+
+func (ss *storeSigner) SharedSecret(pub *wif.PublicKey) ([]byte, error) {
+	return ss.store.SharedSecret(ss.account, ss.role, pub)
+}
+
+// SignTransaction signs the transaction with the account's keys for the given
+// roles and appends the signatures to it.
+func (s *Store) SignTransaction(tx *transaction.SignedTransaction, chain *transaction.Chain, account string, roles ...string) error {
+	if len(roles) == 0 {
+		return errors.New("at least one role is required")
+	}
+
+	digest, err := tx.Digest(chain)
+	if err != nil {
+		return err
+	}
+
+	sigs := make([]string, 0, len(roles))
+	for _, role := range roles {
+		sig, err := s.SignDigest(account, role, digest)
+		if err != nil {
+			return errors.Wrapf(err, "failed to sign with %s key of %s", role, account)
+		}
+		sigs = append(sigs, hex.EncodeToString(sig))
+	}
+
+	tx.Signatures = append(tx.Signatures, sigs...)
+	return nil
+}
+
+func (s *Store) get(account, role string) ([]byte, error) {
+	if s.entries == nil {
+		return nil, ErrLocked
+	}
+	raw, ok := s.entries[entryID{account, role}]
+	if !ok {
+		return nil, errors.Wrapf(ErrKeyNotFound, "%s/%s", account, role)
+	}
+	return raw, nil
+}
+
+func (s *Store) lock() {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	for id, raw := range s.entries {
+		zero(raw)
+		delete(s.entries, id)
+	}
+	s.entries = nil
+	zero(s.key)
+	s.key = nil
+	s.session++
+}
+
+func (s *Store) decrypt(key []byte) (map[entryID][]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := aead.Open(nil, s.file.Nonce, s.file.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+	defer zero(plaintext)
+
+	var list []entry
+	if err := json.Unmarshal(plaintext, &list); err != nil {
+		return nil, errors.Wrap(err, "failed to decode keystore entries")
+	}
+
+	entries := make(map[entryID][]byte, len(list))
+	for _, e := range list {
+		entries[entryID{e.Account, e.Role}] = e.Key
+	}
+	return entries, nil
+}
+
+// save encrypts the entries with a fresh nonce and atomically replaces the
+// keystore file.
+func (s *Store) save() error {
+	list := make([]entry, 0, len(s.entries))
+	for id, raw := range s.entries {
+		list = append(list, entry{Account: id.account, Role: id.role, Key: raw})
+	}
+
+	plaintext, err := json.Marshal(list)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode keystore entries")
+	}
+	defer zero(plaintext)
+
+	aead, err := newAEAD(s.key)
+	if err != nil {
+		return err
+	}
+
+	file := s.file
+	file.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return errors.Wrap(err, "failed to generate nonce")
+	}
+	file.Ciphertext = aead.Seal(nil, file.Nonce, plaintext, nil)
+
+	data, err := json.MarshalIndent(&file, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode keystore")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "failed to write keystore")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "failed to write keystore")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to write keystore")
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return errors.Wrap(err, "failed to write keystore")
+	}
+
+	s.file = file
+	return nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+	return cipher.NewGCM(block)
+}
+
+func publicKey(raw []byte) *wif.PublicKey {
+	privKey := secp256k1.PrivKeyFromBytes(raw)
+	defer privKey.Zero()
+	return &wif.PublicKey{Raw: privKey.PubKey()}
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
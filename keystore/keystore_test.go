@@ -0,0 +1,303 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/signer"
+	"github.com/steemit/steemutil/transaction"
+	"github.com/steemit/steemutil/wif"
+)
+
+var testKDF = &KDFParams{Name: KDFScrypt, N: 1 << 10, R: 8, P: 1}
+
+const testWif = "5JWHY5DxTF6qN5grTtChDCYBmWHfY9zaSsw4CxEKN5eZpH9iBma"
+
+func newTestStore(t *testing.T) (*Store, string) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	s, err := Create(path, "secret", testKDF)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	return s, path
+}
+
+func TestStore_ImportAndReopen(t *testing.T) {
+	s, path := newTestStore(t)
+	if err := s.ImportWif("alice", "posting", testWif); err != nil {
+		t.Fatalf("ImportWif failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privKey := &wif.PrivateKey{}
+	if err := privKey.FromWif(testWif); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), hex.EncodeToString(privKey.ToByte())) || strings.Contains(string(data), testWif) {
+		t.Error("keystore file must not contain the plain key")
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if !reopened.IsLocked() {
+		t.Error("expected an opened keystore to be locked")
+	}
+	if _, err := reopened.Keys(); err != ErrLocked {
+		t.Errorf("expected %v, got %v", ErrLocked, err)
+	}
+
+	if err := reopened.Unlock("wrong", 0); err != ErrInvalidPassphrase {
+		t.Errorf("expected %v, got %v", ErrInvalidPassphrase, err)
+	}
+	if err := reopened.Unlock("secret", 0); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+
+	keys, err := reopened.Keys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := KeyInfo{Account: "alice", Role: "posting", PublicKey: privKey.ToPubKeyStr()}
+	if len(keys) != 1 || keys[0] != expected {
+		t.Errorf("expected %v, got %v", expected, keys)
+	}
+}
+
+func TestStore_Argon2id(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	s, err := Create(path, "secret", &KDFParams{Name: KDFArgon2id, Time: 1, Memory: 1024, Threads: 1})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := s.ImportWif("alice", "active", testWif); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reopened.Unlock("secret", 0); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+	if _, err := reopened.PublicKey("alice", "active"); err != nil {
+		t.Errorf("PublicKey failed: %v", err)
+	}
+}
+
+func TestStore_LockZeroesKeys(t *testing.T) {
+	s, _ := newTestStore(t)
+	if err := s.ImportWif("alice", "posting", testWif); err != nil {
+		t.Fatal(err)
+	}
+
+	raw := s.entries[entryID{"alice", "posting"}]
+	key := s.key
+	s.Lock()
+
+	for _, b := range append(raw, key...) {
+		if b != 0 {
+			t.Fatal("expected key material to be zeroed")
+		}
+	}
+	if _, err := s.SignDigest("alice", "posting", make([]byte, 32)); err != ErrLocked {
+		t.Errorf("expected %v, got %v", ErrLocked, err)
+	}
+}
+
+func TestStore_UnlockTimeout(t *testing.T) {
+	s, path := newTestStore(t)
+	s.Lock()
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reopened.Unlock("secret", 20*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if reopened.IsLocked() {
+		t.Fatal("expected the keystore to be unlocked")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !reopened.IsLocked() {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the keystore to lock after the timeout")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestStore_UnlockTimeoutOfEarlierSession(t *testing.T) {
+	s, _ := newTestStore(t)
+	if err := s.Unlock("secret", 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	// Let the timer fire while the store is busy, then lock and unlock it
+	// again before the timer gets the mutex.
+	s.mu.Lock()
+	time.Sleep(50 * time.Millisecond)
+	s.lock()
+	s.mu.Unlock()
+	if err := s.Unlock("secret", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if s.IsLocked() {
+		t.Error("expected the timer of an earlier session not to lock the store")
+	}
+}
+
+func TestOpen_KDFBounds(t *testing.T) {
+	for _, kdf := range []KDFParams{
+		{Name: KDFScrypt, N: 1 << 30, R: 8, P: 1},
+		{Name: KDFScrypt, N: 1000, R: 8, P: 1},
+		{Name: KDFScrypt, N: 1 << 20, R: 32, P: 1},
+		{Name: KDFScrypt, N: 1 << 10, R: 8, P: 1 << 20},
+		{Name: KDFArgon2id, Time: 1, Memory: 1 << 31, Threads: 1},
+		{Name: KDFArgon2id, Time: 1 << 20, Memory: 1024, Threads: 1},
+		{Name: KDFArgon2id, Time: 1, Memory: 1024, Threads: 0},
+		{Name: "pbkdf2"},
+	} {
+		_, path := newTestStore(t)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var file keyFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			t.Fatal(err)
+		}
+		kdf.Salt = file.KDF.Salt
+		file.KDF = kdf
+		if data, err = json.Marshal(&file); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Open(path); err == nil {
+			t.Errorf("expected %+v to be rejected", kdf)
+		}
+	}
+}
+
+func TestStore_SignTransaction(t *testing.T) {
+	s, _ := newTestStore(t)
+	if err := s.ImportWif("xeroc", "posting", testWif); err != nil {
+		t.Fatal(err)
+	}
+
+	expiration := time.Date(2016, 8, 8, 12, 24, 17, 0, time.UTC)
+	tx := transaction.NewSignedTransaction(&transaction.Transaction{
+		RefBlockNum:    36029,
+		RefBlockPrefix: 1164960351,
+		Expiration:     &protocol.Time{Time: &expiration},
+	})
+	tx.PushOperation(&protocol.VoteOperation{Voter: "xeroc", Author: "xeroc", Permlink: "piston", Weight: 10000})
+
+	if err := s.SignTransaction(tx, transaction.SteemChain, "xeroc", "posting"); err != nil {
+		t.Fatalf("SignTransaction failed: %v", err)
+	}
+	if len(tx.Signatures) != 1 {
+		t.Fatalf("expected 1 signature, got %d", len(tx.Signatures))
+	}
+
+	digest, err := tx.Digest(transaction.SteemChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := hex.DecodeString(tx.Signatures[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey, err := s.PublicKey("xeroc", "posting")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pubKey.VerifySha256(digest, sig) {
+		t.Error("signature does not verify")
+	}
+
+	if err := s.SignTransaction(tx, transaction.SteemChain, "xeroc", "active"); err == nil {
+		t.Error("expected error for missing key")
+	}
+}
+
+func TestStore_Remove(t *testing.T) {
+	s, path := newTestStore(t)
+	if err := s.ImportWif("alice", "posting", testWif); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Remove("alice", "posting"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := s.Remove("alice", "posting"); err != ErrKeyNotFound {
+		t.Errorf("expected %v, got %v", ErrKeyNotFound, err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reopened.Unlock("secret", 0); err != nil {
+		t.Fatal(err)
+	}
+	if keys, _ := reopened.Keys(); len(keys) != 0 {
+		t.Errorf("expected no keys, got %v", keys)
+	}
+
+	if _, err := Create(path, "secret", testKDF); err == nil {
+		t.Error("expected error when the keystore exists")
+	}
+}
+
+func TestStore_Signer(t *testing.T) {
+	s, _ := newTestStore(t)
+	if err := s.ImportWif("alice", "memo", testWif); err != nil {
+		t.Fatal(err)
+	}
+
+	storeSigner, err := s.Signer("alice", "memo")
+	if err != nil {
+		t.Fatalf("Signer failed: %v", err)
+	}
+	local, err := signer.FromWif(testWif)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if storeSigner.PublicKey().ToStr() != local.PublicKey().ToStr() {
+		t.Errorf("expected %v, got %v", local.PublicKey().ToStr(), storeSigner.PublicKey().ToStr())
+	}
+
+	other, err := wif.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret, err := storeSigner.SharedSecret(other.ToPublicKey())
+	if err != nil {
+		t.Fatalf("SharedSecret failed: %v", err)
+	}
+	if string(secret) != string(other.SharedSecret(local.PublicKey())) {
+		t.Error("unexpected shared secret")
+	}
+
+	s.Lock()
+	if _, err := storeSigner.SignDigest(make([]byte, 32)); err != ErrLocked {
+		t.Errorf("expected %v, got %v", ErrLocked, err)
+	}
+}
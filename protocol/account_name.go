@@ -0,0 +1,149 @@
+package protocol
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/consts"
+)
+
+// Reasons for an account name to be invalid, wrapped by AccountNameError.
+var (
+	ErrAccountNameTooShort        = errors.New("name is too short")
+	ErrAccountNameTooLong         = errors.New("name is too long")
+	ErrAccountNameSegmentTooShort = errors.New("each dot separated segment must have at least 3 characters")
+	ErrAccountNameSegmentStart    = errors.New("each segment must start with a lowercase letter")
+	ErrAccountNameSegmentEnd      = errors.New("each segment must end with a lowercase letter or digit")
+	ErrAccountNameCharacter       = errors.New("only lowercase letters, digits, dashes and dots are allowed")
+)
+
+// AccountNameError describes why a name is not a valid account name. Pos is
+// the byte offset of the offending character or segment, -1 for length
+// errors. Use errors.Is with the ErrAccountName* values to test the reason.
+type AccountNameError struct {
+	Name   string
+	Pos    int
+	Reason error
+}
+
+func (e *AccountNameError) Error() string {
+	if e.Pos < 0 {
+		return fmt.Sprintf("invalid account name %q: %v", e.Name, e.Reason)
+	}
+	return fmt.Sprintf("invalid account name %q at position %d: %v", e.Name, e.Pos, e.Reason)
+}
+
+func (e *AccountNameError) Unwrap() error {
+	return e.Reason
+}
+
+// ValidateAccountName implements steemd's is_valid_account_name: 3 to 16
+// characters, dot separated segments of at least 3 characters, each starting
+// with a letter, ending with a letter or digit and otherwise made of
+// lowercase letters, digits and dashes. It returns an *AccountNameError.
+func ValidateAccountName(name string) error {
+	cfg := consts.SteemMainnet
+	if len(name) < cfg.MinAccountNameLength {
+		return &AccountNameError{Name: name, Pos: -1, Reason: ErrAccountNameTooShort}
+	}
+	if len(name) > cfg.MaxAccountNameLength {
+		return &AccountNameError{Name: name, Pos: -1, Reason: ErrAccountNameTooLong}
+	}
+
+	start := 0
+	for _, segment := range strings.Split(name, ".") {
+		for i := 0; i < len(segment); i++ {
+			if c := segment[i]; !isNameLetter(c) && !isNameDigit(c) && c != '-' {
+				return &AccountNameError{Name: name, Pos: start + i, Reason: ErrAccountNameCharacter}
+			}
+		}
+		switch {
+		case len(segment) < 3:
+			return &AccountNameError{Name: name, Pos: start, Reason: ErrAccountNameSegmentTooShort}
+		case !isNameLetter(segment[0]):
+			return &AccountNameError{Name: name, Pos: start, Reason: ErrAccountNameSegmentStart}
+		case !isNameLetter(segment[len(segment)-1]) && !isNameDigit(segment[len(segment)-1]):
+			return &AccountNameError{Name: name, Pos: start + len(segment) - 1, Reason: ErrAccountNameSegmentEnd}
+		}
+		start += len(segment) + 1
+	}
+	return nil
+}
+
+// IsValidAccountName reports whether name is a valid account name.
+func IsValidAccountName(name string) bool {
+	return ValidateAccountName(name) == nil
+}
+
+// SuggestAccountName derives a valid account name from user input, e.g.
+// "John Smith" becomes "john-smith". Letters are lowercased, spaces and
+// punctuation become dashes, other characters are dropped, short segments
+// are joined to their neighbours and the result is cut to the maximum
+// length. The suggestion may be taken on chain already.
+func SuggestAccountName(input string) (string, error) {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(input)) {
+		switch {
+		case r < 0x80 && (isNameLetter(byte(r)) || isNameDigit(byte(r)) || r == '.'):
+			b.WriteRune(r)
+		case r < 0x80:
+			b.WriteByte('-')
+		}
+	}
+
+	var segments []string
+	pending := ""
+	for _, segment := range strings.Split(b.String(), ".") {
+		for strings.Contains(segment, "--") {
+			segment = strings.ReplaceAll(segment, "--", "-")
+		}
+		segment = strings.TrimLeft(segment, "-0123456789")
+		segment = strings.TrimRight(segment, "-")
+		if segment == "" {
+			continue
+		}
+		if pending != "" {
+			segment, pending = pending+"-"+segment, ""
+		}
+		if len(segment) < 3 {
+			if len(segments) > 0 {
+				segments[len(segments)-1] += "-" + segment
+			} else {
+				pending = segment
+			}
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	if pending != "" {
+		segments = append(segments, pending)
+	}
+
+	name := strings.Join(segments, ".")
+	if name == "" {
+		return "", errors.Errorf("cannot derive an account name from %q", input)
+	}
+	if max := consts.SteemMainnet.MaxAccountNameLength; len(name) > max {
+		name = strings.TrimRight(name[:max], "-.")
+		if dot := strings.LastIndexByte(name, '.'); dot >= 0 && len(name)-dot-1 < 3 {
+			name = strings.TrimRight(name[:dot], "-")
+		}
+	}
+	for len(name) < consts.SteemMainnet.MinAccountNameLength {
+		name += "0"
+	}
+
+	if err := ValidateAccountName(name); err != nil {
+		return "", errors.Wrapf(err, "cannot derive an account name from %q", input)
+	}
+	return name, nil
+}
+
+func isNameLetter(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
+
+func isNameDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
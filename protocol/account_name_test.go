@@ -0,0 +1,89 @@
+package protocol
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateAccountName(t *testing.T) {
+	valid := []string{"abc", "alice", "steemit", "a-b-c", "bob.smith", "abc.def.ghi", "a12", "abcdefghijklmnop"}
+	for _, name := range valid {
+		if err := ValidateAccountName(name); err != nil {
+			t.Errorf("expected %q to be valid, got %v", name, err)
+		}
+		if !IsValidAccountName(name) {
+			t.Errorf("expected IsValidAccountName(%q) to be true", name)
+		}
+	}
+
+	invalid := []struct {
+		name   string
+		reason error
+		pos    int
+	}{
+		{"", ErrAccountNameTooShort, -1},
+		{"ab", ErrAccountNameTooShort, -1},
+		{"abcdefghijklmnopq", ErrAccountNameTooLong, -1},
+		{"Alice", ErrAccountNameCharacter, 0},
+		{"al_ice", ErrAccountNameCharacter, 2},
+		{"ali ce", ErrAccountNameCharacter, 3},
+		{"1abc", ErrAccountNameSegmentStart, 0},
+		{"-abc", ErrAccountNameSegmentStart, 0},
+		{"abc.1de", ErrAccountNameSegmentStart, 4},
+		{"abc-", ErrAccountNameSegmentEnd, 3},
+		{"ab.cde", ErrAccountNameSegmentTooShort, 0},
+		{"abc..def", ErrAccountNameSegmentTooShort, 4},
+		{"abc.", ErrAccountNameSegmentTooShort, 4},
+	}
+	for _, tt := range invalid {
+		err := ValidateAccountName(tt.name)
+		if !errors.Is(err, tt.reason) {
+			t.Errorf("%q: expected %v, got %v", tt.name, tt.reason, err)
+			continue
+		}
+		var nameErr *AccountNameError
+		if !errors.As(err, &nameErr) || nameErr.Pos != tt.pos {
+			t.Errorf("%q: expected position %v, got %v", tt.name, tt.pos, err)
+		}
+		if IsValidAccountName(tt.name) {
+			t.Errorf("expected IsValidAccountName(%q) to be false", tt.name)
+		}
+	}
+}
+
+func TestSuggestAccountName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"alice", "alice"},
+		{"John Smith", "john-smith"},
+		{"  Bob_The__Builder!! ", "bob-the-builder"},
+		{"123abc", "abc"},
+		{"Jo", "jo0"},
+		{"j.r.r.tolkien", "j-r-r.tolkien"},
+		{"mr.x", "mr-x"},
+		{"averyveryverylongname", "averyveryverylon"},
+		{"steem.community.builders", "steem.community"},
+		{"Zoë", "zo0"},
+	}
+	for _, tt := range tests {
+		got, err := SuggestAccountName(tt.input)
+		if err != nil {
+			t.Errorf("%q: %v", tt.input, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("%q: expected %v, got %v", tt.input, tt.expected, got)
+		}
+		if !IsValidAccountName(got) {
+			t.Errorf("%q: suggested invalid name %v", tt.input, got)
+		}
+	}
+
+	for _, input := range []string{"", "1234", "---", "日本"} {
+		if name, err := SuggestAccountName(input); err == nil {
+			t.Errorf("%q: expected an error, got %v", input, name)
+		}
+	}
+}
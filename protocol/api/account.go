@@ -0,0 +1,75 @@
+package api
+
+import "github.com/steemit/steemutil/protocol"
+
+// Account is an entry of the condenser_api.get_accounts result.
+type Account struct {
+	Id                            protocol.UInt       `json:"id"`
+	Name                          string              `json:"name"`
+	Owner                         *protocol.Authority `json:"owner"`
+	Active                        *protocol.Authority `json:"active"`
+	Posting                       *protocol.Authority `json:"posting"`
+	MemoKey                       string              `json:"memo_key"`
+	JsonMetadata                  string              `json:"json_metadata"`
+	PostingJsonMetadata           string              `json:"posting_json_metadata"`
+	Proxy                         string              `json:"proxy"`
+	LastOwnerUpdate               *protocol.Time      `json:"last_owner_update"`
+	LastAccountUpdate             *protocol.Time      `json:"last_account_update"`
+	Created                       *protocol.Time      `json:"created"`
+	Mined                         bool                `json:"mined"`
+	RecoveryAccount               string              `json:"recovery_account"`
+	LastAccountRecovery           *protocol.Time      `json:"last_account_recovery"`
+	ResetAccount                  string              `json:"reset_account"`
+	CommentCount                  protocol.UInt32     `json:"comment_count"`
+	LifetimeVoteCount             protocol.UInt32     `json:"lifetime_vote_count"`
+	PostCount                     protocol.UInt32     `json:"post_count"`
+	CanVote                       bool                `json:"can_vote"`
+	VotingManabar                 protocol.Manabar    `json:"voting_manabar"`
+	DownvoteManabar               protocol.Manabar    `json:"downvote_manabar"`
+	VotingPower                   protocol.UInt16     `json:"voting_power"`
+	Balance                       Asset               `json:"balance"`
+	SavingsBalance                Asset               `json:"savings_balance"`
+	SbdBalance                    Asset               `json:"sbd_balance"`
+	SbdSeconds                    Number              `json:"sbd_seconds"`
+	SbdSecondsLastUpdate          *protocol.Time      `json:"sbd_seconds_last_update"`
+	SbdLastInterestPayment        *protocol.Time      `json:"sbd_last_interest_payment"`
+	SavingsSbdBalance             Asset               `json:"savings_sbd_balance"`
+	SavingsSbdSeconds             Number              `json:"savings_sbd_seconds"`
+	SavingsSbdSecondsLastUpdate   *protocol.Time      `json:"savings_sbd_seconds_last_update"`
+	SavingsSbdLastInterestPayment *protocol.Time      `json:"savings_sbd_last_interest_payment"`
+	SavingsWithdrawRequests       protocol.UInt8      `json:"savings_withdraw_requests"`
+	RewardSbdBalance              Asset               `json:"reward_sbd_balance"`
+	RewardSteemBalance            Asset               `json:"reward_steem_balance"`
+	RewardVestingBalance          Asset               `json:"reward_vesting_balance"`
+	RewardVestingSteem            Asset               `json:"reward_vesting_steem"`
+	VestingShares                 Asset               `json:"vesting_shares"`
+	DelegatedVestingShares        Asset               `json:"delegated_vesting_shares"`
+	ReceivedVestingShares         Asset               `json:"received_vesting_shares"`
+	VestingWithdrawRate           Asset               `json:"vesting_withdraw_rate"`
+	NextVestingWithdrawal         *protocol.Time      `json:"next_vesting_withdrawal"`
+	Withdrawn                     protocol.Int64      `json:"withdrawn"`
+	ToWithdraw                    protocol.Int64      `json:"to_withdraw"`
+	WithdrawRoutes                protocol.UInt16     `json:"withdraw_routes"`
+	CurationRewards               protocol.Int64      `json:"curation_rewards"`
+	PostingRewards                protocol.Int64      `json:"posting_rewards"`
+	ProxiedVsfVotes               []protocol.Int64    `json:"proxied_vsf_votes"`
+	WitnessesVotedFor             protocol.UInt16     `json:"witnesses_voted_for"`
+	LastPost                      *protocol.Time      `json:"last_post"`
+	LastRootPost                  *protocol.Time      `json:"last_root_post"`
+	LastVoteTime                  *protocol.Time      `json:"last_vote_time"`
+	PostBandwidth                 protocol.UInt32     `json:"post_bandwidth"`
+	PendingClaimedAccounts        protocol.Int64      `json:"pending_claimed_accounts"`
+	VestingBalance                Asset               `json:"vesting_balance"`
+	Reputation                    protocol.Int64      `json:"reputation"`
+	WitnessVotes                  []string            `json:"witness_votes"`
+}
+
+// VestingDelegation is an entry of the condenser_api.get_vesting_delegations
+// result.
+type VestingDelegation struct {
+	Id                protocol.UInt  `json:"id"`
+	Delegator         string         `json:"delegator"`
+	Delegatee         string         `json:"delegatee"`
+	VestingShares     Asset          `json:"vesting_shares"`
+	MinDelegationTime *protocol.Time `json:"min_delegation_time"`
+}
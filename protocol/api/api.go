@@ -68,3 +68,69 @@ type Transaction struct {
 	BlockNum       protocol.UInt32     `json:"block_num"`
 	TransactionNum protocol.UInt       `json:"transaction_num"`
 }
+
+type RewardFund struct {
+	Id                     protocol.UInt  `json:"id"`
+	Name                   string         `json:"name"`
+	RewardBalance          Asset          `json:"reward_balance"`
+	RecentClaims           Number         `json:"recent_claims"`
+	LastUpdate             *protocol.Time `json:"last_update"`
+	ContentConstant        Number         `json:"content_constant"`
+	PercentCurationRewards protocol.UInt  `json:"percent_curation_rewards"`
+	PercentContentRewards  protocol.UInt  `json:"percent_content_rewards"`
+	AuthorRewardCurve      string         `json:"author_reward_curve"`
+	CurationRewardCurve    string         `json:"curation_reward_curve"`
+}
+
+type RCPriceCurveParams struct {
+	CoeffA protocol.UInt64 `json:"coeff_a"`
+	CoeffB protocol.UInt64 `json:"coeff_b"`
+	Shift  protocol.UInt8  `json:"shift"`
+}
+
+type RCDecayParams struct {
+	DecayPerTimeUnit           protocol.UInt64 `json:"decay_per_time_unit"`
+	DecayPerTimeUnitDenomShift protocol.UInt8  `json:"decay_per_time_unit_denom_shift"`
+}
+
+type RCResourceDynamicsParams struct {
+	ResourceUnit      protocol.UInt64 `json:"resource_unit"`
+	BudgetPerTimeUnit protocol.Int64  `json:"budget_per_time_unit"`
+	PoolEq            protocol.UInt64 `json:"pool_eq"`
+	MaxPoolSize       protocol.UInt64 `json:"max_pool_size"`
+	DecayParams       RCDecayParams   `json:"decay_params"`
+	MinDecay          protocol.Int64  `json:"min_decay"`
+}
+
+type RCResourceParam struct {
+	ResourceDynamicsParams RCResourceDynamicsParams `json:"resource_dynamics_params"`
+	PriceCurveParams       RCPriceCurveParams       `json:"price_curve_params"`
+}
+
+// RCResourceParams is the result of rc_api.get_resource_params.
+type RCResourceParams struct {
+	ResourceNames  []string                             `json:"resource_names"`
+	ResourceParams map[string]RCResourceParam           `json:"resource_params"`
+	SizeInfo       map[string]map[string]protocol.Int64 `json:"size_info"`
+}
+
+type RCPool struct {
+	Pool protocol.Int64 `json:"pool"`
+}
+
+// RCResourcePool is the result of rc_api.get_resource_pool.
+type RCResourcePool struct {
+	ResourcePool map[string]RCPool `json:"resource_pool"`
+}
+
+type RCAccount struct {
+	Account                 string           `json:"account"`
+	RCManabar               protocol.Manabar `json:"rc_manabar"`
+	MaxRCCreationAdjustment any              `json:"max_rc_creation_adjustment"`
+	MaxRC                   protocol.Int64   `json:"max_rc"`
+}
+
+// RCAccounts is the result of rc_api.find_rc_accounts.
+type RCAccounts struct {
+	RCAccounts []RCAccount `json:"rc_accounts"`
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/consts"
+	"github.com/steemit/steemutil/protocol"
+)
+
+// NAIChain maps the NAIs of assets in the NAI object form to symbols. The
+// core NAIs are the same on every chain, only the symbols differ.
+var NAIChain = consts.SteemMainnet
+
+// Asset is an asset in the legacy string form, e.g. "1.000 STEEM". It also
+// decodes the NAI object form returned by appbase APIs, e.g.
+// {"amount":"1000","precision":3,"nai":"@@000000021"}, converting it with
+// NAIChain.
+type Asset string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *Asset) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return errors.Wrapf(err, "invalid asset: %s", data)
+		}
+		*a = Asset(s)
+		return nil
+	}
+
+	var nai struct {
+		Amount    json.Number `json:"amount"`
+		Precision uint8       `json:"precision"`
+		NAI       string      `json:"nai"`
+	}
+	if err := json.Unmarshal(data, &nai); err != nil {
+		return errors.Wrapf(err, "invalid asset: %s", data)
+	}
+	asset, err := (&protocol.NAIAsset{
+		Amount:    nai.Amount.String(),
+		Precision: nai.Precision,
+		NAI:       nai.NAI,
+	}).ToAsset(NAIChain)
+	if err != nil {
+		return errors.Wrapf(err, "invalid asset: %s", data)
+	}
+	*a = Asset(asset.String())
+	return nil
+}
+
+// Parse parses the asset.
+func (a Asset) Parse() (*protocol.Asset, error) {
+	return protocol.ParseAsset(string(a))
+}
+
+// Price is an exchange rate between two assets.
+type Price struct {
+	Base  Asset `json:"base"`
+	Quote Asset `json:"quote"`
+}
+
+// Number is an integer kept in its decimal string form, for uint128 and
+// other values that may exceed int64. It decodes JSON strings and numbers.
+type Number string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *Number) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return errors.Wrapf(err, "invalid number: %s", data)
+		}
+		*n = Number(s)
+		return nil
+	}
+	var num json.Number
+	if err := json.Unmarshal(data, &num); err != nil {
+		return errors.Wrapf(err, "invalid number: %s", data)
+	}
+	*n = Number(num.String())
+	return nil
+}
+
+// Int returns the number as a big.Int.
+func (n Number) Int() (*big.Int, error) {
+	i, ok := new(big.Int).SetString(string(n), 10)
+	if !ok {
+		return nil, errors.Errorf("invalid number: %s", n)
+	}
+	return i, nil
+}
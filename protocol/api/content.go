@@ -0,0 +1,68 @@
+package api
+
+import "github.com/steemit/steemutil/protocol"
+
+// Content is the result of condenser_api.get_content and the entries of
+// the discussion queries.
+type Content struct {
+	Id                      protocol.UInt          `json:"id"`
+	Author                  string                 `json:"author"`
+	Permlink                string                 `json:"permlink"`
+	Category                string                 `json:"category"`
+	ParentAuthor            string                 `json:"parent_author"`
+	ParentPermlink          string                 `json:"parent_permlink"`
+	Title                   string                 `json:"title"`
+	Body                    string                 `json:"body"`
+	JsonMetadata            string                 `json:"json_metadata"`
+	LastUpdate              *protocol.Time         `json:"last_update"`
+	Created                 *protocol.Time         `json:"created"`
+	Active                  *protocol.Time         `json:"active"`
+	LastPayout              *protocol.Time         `json:"last_payout"`
+	Depth                   protocol.UInt16        `json:"depth"`
+	Children                protocol.UInt32        `json:"children"`
+	NetRshares              protocol.Int64         `json:"net_rshares"`
+	AbsRshares              protocol.Int64         `json:"abs_rshares"`
+	VoteRshares             protocol.Int64         `json:"vote_rshares"`
+	ChildrenAbsRshares      protocol.Int64         `json:"children_abs_rshares"`
+	CashoutTime             *protocol.Time         `json:"cashout_time"`
+	MaxCashoutTime          *protocol.Time         `json:"max_cashout_time"`
+	TotalVoteWeight         protocol.UInt64        `json:"total_vote_weight"`
+	RewardWeight            protocol.UInt16        `json:"reward_weight"`
+	TotalPayoutValue        Asset                  `json:"total_payout_value"`
+	CuratorPayoutValue      Asset                  `json:"curator_payout_value"`
+	AuthorRewards           protocol.Int64         `json:"author_rewards"`
+	NetVotes                protocol.Int32         `json:"net_votes"`
+	RootAuthor              string                 `json:"root_author"`
+	RootPermlink            string                 `json:"root_permlink"`
+	MaxAcceptedPayout       Asset                  `json:"max_accepted_payout"`
+	PercentSteemDollars     protocol.UInt16        `json:"percent_steem_dollars"`
+	AllowReplies            bool                   `json:"allow_replies"`
+	AllowVotes              bool                   `json:"allow_votes"`
+	AllowCurationRewards    bool                   `json:"allow_curation_rewards"`
+	Beneficiaries           []protocol.Beneficiary `json:"beneficiaries"`
+	URL                     string                 `json:"url"`
+	RootTitle               string                 `json:"root_title"`
+	PendingPayoutValue      Asset                  `json:"pending_payout_value"`
+	TotalPendingPayoutValue Asset                  `json:"total_pending_payout_value"`
+	ActiveVotes             []VoteState            `json:"active_votes"`
+	Replies                 []string               `json:"replies"`
+	AuthorReputation        protocol.Int64         `json:"author_reputation"`
+	Promoted                Asset                  `json:"promoted"`
+	BodyLength              protocol.UInt32        `json:"body_length"`
+	RebloggedBy             []string               `json:"reblogged_by"`
+}
+
+// IsStory reports whether the content is a root post.
+func (c *Content) IsStory() bool {
+	return c.ParentAuthor == ""
+}
+
+// VoteState is an entry of the condenser_api.get_active_votes result.
+type VoteState struct {
+	Voter      string          `json:"voter"`
+	Weight     protocol.UInt64 `json:"weight"`
+	Rshares    protocol.Int64  `json:"rshares"`
+	Percent    protocol.Int16  `json:"percent"`
+	Reputation protocol.Int64  `json:"reputation"`
+	Time       *protocol.Time  `json:"time"`
+}
@@ -1,6 +1,11 @@
 package api
 
-import "github.com/steemit/steemutil/protocol"
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/protocol"
+)
 
 type RpcSendData struct {
 	Id      protocol.UInt `json:"id"`
@@ -9,9 +14,61 @@ type RpcSendData struct {
 	Params  []any         `json:"params"`
 }
 
+// RpcObjectSendData is a request whose params are an object, for methods
+// whose APIMethod.IsObject is set.
+type RpcObjectSendData struct {
+	Id      protocol.UInt  `json:"id"`
+	JsonRpc string         `json:"jsonrpc"`
+	Method  string         `json:"method"`
+	Params  map[string]any `json:"params"`
+}
+
 type RpcResultData struct {
 	Id      protocol.UInt `json:"id"`
 	JsonRpc string        `json:"jsonrpc"`
 	Result  any           `json:"result,omitempty"`
 	Error   any           `json:"error,omitempty"`
+
+	// rawResult keeps the undecoded result, so that DecodeResult does not
+	// lose the precision of large integers decoded into Result as float64.
+	rawResult json.RawMessage
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *RpcResultData) UnmarshalJSON(data []byte) error {
+	var res struct {
+		Id      protocol.UInt   `json:"id"`
+		JsonRpc string          `json:"jsonrpc"`
+		Result  json.RawMessage `json:"result"`
+		Error   any             `json:"error"`
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+	r.Id, r.JsonRpc, r.Error = res.Id, res.JsonRpc, res.Error
+	r.Result, r.rawResult = nil, res.Result
+	if len(res.Result) > 0 {
+		if err := json.Unmarshal(res.Result, &r.Result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeResult decodes Result into v, e.g. a *[]Account for get_accounts.
+func (r *RpcResultData) DecodeResult(v any) error {
+	if r.Error != nil {
+		return errors.Errorf("rpc error: %v", r.Error)
+	}
+	data := []byte(r.rawResult)
+	if len(data) == 0 {
+		var err error
+		if data, err = json.Marshal(r.Result); err != nil {
+			return errors.Wrap(err, "failed to read rpc result")
+		}
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.Wrap(err, "failed to decode rpc result")
+	}
+	return nil
 }
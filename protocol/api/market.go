@@ -0,0 +1,23 @@
+package api
+
+import "github.com/steemit/steemutil/protocol"
+
+// OpenOrder is an entry of the condenser_api.get_open_orders result.
+type OpenOrder struct {
+	Id         protocol.UInt   `json:"id"`
+	Created    *protocol.Time  `json:"created"`
+	Expiration *protocol.Time  `json:"expiration"`
+	Seller     string          `json:"seller"`
+	OrderId    protocol.UInt32 `json:"orderid"`
+	ForSale    protocol.Int64  `json:"for_sale"`
+	SellPrice  Price           `json:"sell_price"`
+	RealPrice  string          `json:"real_price"`
+	Rewarded   bool            `json:"rewarded"`
+}
+
+// FeedHistory is the result of condenser_api.get_feed_history.
+type FeedHistory struct {
+	Id                   protocol.UInt `json:"id"`
+	CurrentMedianHistory Price         `json:"current_median_history"`
+	PriceHistory         []Price       `json:"price_history"`
+}
@@ -0,0 +1,274 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// decodeResult decodes the result of a JSON-RPC response into v.
+func decodeResult(t *testing.T, data string, v any) {
+	t.Helper()
+	var res RpcResultData
+	if err := json.Unmarshal([]byte(data), &res); err != nil {
+		t.Fatal(err)
+	}
+	if err := res.DecodeResult(v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAssetUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Asset
+	}{
+		{`"1.000 STEEM"`, "1.000 STEEM"},
+		{`{"amount":"1000","precision":3,"nai":"@@000000021"}`, "1.000 STEEM"},
+		{`{"amount":"8","precision":3,"nai":"@@000000013"}`, "0.008 SBD"},
+		{`{"amount":123456789,"precision":6,"nai":"@@000000037"}`, "123.456789 VESTS"},
+	}
+	for _, tt := range tests {
+		var a Asset
+		if err := json.Unmarshal([]byte(tt.in), &a); err != nil {
+			t.Errorf("%s: %v", tt.in, err)
+			continue
+		}
+		if a != tt.want {
+			t.Errorf("expected %v, got %v", tt.want, a)
+		}
+	}
+
+	var a Asset
+	if err := json.Unmarshal([]byte(`{"amount":"1","precision":3,"nai":"@@000000099"}`), &a); err == nil {
+		t.Error("expected an error for an unknown NAI")
+	}
+}
+
+func TestNumberUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Number
+	}{
+		{`"340282366920938463463374607431768211455"`, "340282366920938463463374607431768211455"},
+		{`2000000000000`, "2000000000000"},
+		{`0`, "0"},
+	}
+	for _, tt := range tests {
+		var n Number
+		if err := json.Unmarshal([]byte(tt.in), &n); err != nil {
+			t.Errorf("%s: %v", tt.in, err)
+			continue
+		}
+		if n != tt.want {
+			t.Errorf("expected %v, got %v", tt.want, n)
+		}
+		if _, err := n.Int(); err != nil {
+			t.Errorf("%s: %v", tt.in, err)
+		}
+	}
+
+	if _, err := Number("1.5").Int(); err == nil {
+		t.Error("expected an error for a non-integer number")
+	}
+}
+
+func TestDecodeResultKeepsPrecision(t *testing.T) {
+	var res RpcResultData
+	if err := json.Unmarshal([]byte(`{"id":1,"jsonrpc":"2.0","result":{"votes":27936735712341263}}`), &res); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := res.Result.(map[string]any); !ok {
+		t.Errorf("expected Result to stay a generic map, got %T", res.Result)
+	}
+	var w Witness
+	if err := res.DecodeResult(&w); err != nil {
+		t.Fatal(err)
+	}
+	if w.Votes != "27936735712341263" {
+		t.Errorf("expected %v, got %v", "27936735712341263", w.Votes)
+	}
+}
+
+func TestDecodeResultError(t *testing.T) {
+	var res RpcResultData
+	if err := json.Unmarshal([]byte(`{"id":1,"jsonrpc":"2.0","error":{"code":-32000,"message":"boom"}}`), &res); err != nil {
+		t.Fatal(err)
+	}
+	var v any
+	if err := res.DecodeResult(&v); err == nil {
+		t.Error("expected an error for an rpc error response")
+	}
+}
+
+func TestDecodeResultFromResult(t *testing.T) {
+	res := RpcResultData{Result: map[string]any{"name": "post", "recent_claims": "42"}}
+	var fund RewardFund
+	if err := res.DecodeResult(&fund); err != nil {
+		t.Fatal(err)
+	}
+	if fund.Name != "post" || fund.RecentClaims != "42" {
+		t.Errorf("unexpected reward fund %+v", fund)
+	}
+}
+
+// The responses below only keep the fields whose encoding differs between
+// node versions: legacy and NAI assets, and numbers sent as strings.
+
+func TestDecodeAccounts(t *testing.T) {
+	var accounts []Account
+	decodeResult(t, `{"jsonrpc":"2.0","id":1,"result":[{
+		"name":"steemit",
+		"posting":{"weight_threshold":1,"account_auths":[["busy.app",1]],"key_auths":[["STM6Ezkzey8FWoEnnHHP4rxbrysJqoMmzwR2EdoD5p7FDsF64qxbQ",1]]},
+		"voting_manabar":{"current_mana":"9999999999999999","last_update_time":1597046892},
+		"balance":"0.001 STEEM",
+		"sbd_balance":{"amount":"8","precision":3,"nai":"@@000000013"},
+		"vesting_shares":{"amount":"90039851836689887","precision":6,"nai":"@@000000037"},
+		"proxied_vsf_votes":["12345678901234567",0,0,0],
+		"reputation":"12944616889"}]}`, &accounts)
+	if len(accounts) != 1 {
+		t.Fatalf("expected 1 account, got %d", len(accounts))
+	}
+	acc := accounts[0]
+	if acc.Name != "steemit" {
+		t.Errorf("expected %v, got %v", "steemit", acc.Name)
+	}
+	if acc.Balance != "0.001 STEEM" {
+		t.Errorf("expected %v, got %v", "0.001 STEEM", acc.Balance)
+	}
+	if acc.SbdBalance != "0.008 SBD" {
+		t.Errorf("expected %v, got %v", "0.008 SBD", acc.SbdBalance)
+	}
+	if acc.VestingShares != "90039851836.689887 VESTS" {
+		t.Errorf("expected %v, got %v", "90039851836.689887 VESTS", acc.VestingShares)
+	}
+	if acc.Reputation != 12944616889 {
+		t.Errorf("expected %v, got %v", 12944616889, acc.Reputation)
+	}
+	if acc.VotingManabar.CurrentMana != 9999999999999999 {
+		t.Errorf("expected %v, got %v", 9999999999999999, acc.VotingManabar.CurrentMana)
+	}
+	if len(acc.ProxiedVsfVotes) != 4 || acc.ProxiedVsfVotes[0] != 12345678901234567 {
+		t.Errorf("unexpected proxied_vsf_votes %v", acc.ProxiedVsfVotes)
+	}
+	if acc.Posting == nil || acc.Posting.AccountAuths["busy.app"] != 1 {
+		t.Errorf("unexpected posting authority %+v", acc.Posting)
+	}
+	if _, err := acc.VestingShares.Parse(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDecodeContent(t *testing.T) {
+	var content Content
+	decodeResult(t, `{"jsonrpc":"2.0","id":1,"result":{
+		"author":"steemit","permlink":"firstpost","parent_author":"","depth":0,
+		"total_vote_weight":"18446744073709551615",
+		"curator_payout_value":{"amount":"756","precision":3,"nai":"@@000000013"},
+		"beneficiaries":[{"account":"steem","weight":1000}],
+		"active_votes":[
+			{"voter":"dantheman","weight":"90000000000","rshares":"375241","percent":100,"reputation":0},
+			{"voter":"smooth","weight":0,"rshares":-1000,"percent":"-10000","reputation":"3117"}]}}`, &content)
+	if !content.IsStory() {
+		t.Error("expected a root post")
+	}
+	if content.CuratorPayoutValue != "0.756 SBD" {
+		t.Errorf("expected %v, got %v", "0.756 SBD", content.CuratorPayoutValue)
+	}
+	if content.TotalVoteWeight != 18446744073709551615 {
+		t.Errorf("expected %v, got %v", uint64(18446744073709551615), content.TotalVoteWeight)
+	}
+	if len(content.Beneficiaries) != 1 || content.Beneficiaries[0].Weight != 1000 {
+		t.Errorf("unexpected beneficiaries %v", content.Beneficiaries)
+	}
+	if len(content.ActiveVotes) != 2 {
+		t.Fatalf("expected 2 votes, got %d", len(content.ActiveVotes))
+	}
+	if vote := content.ActiveVotes[0]; vote.Rshares != 375241 || vote.Percent != 100 {
+		t.Errorf("unexpected vote %+v", vote)
+	}
+	if vote := content.ActiveVotes[1]; vote.Rshares != -1000 || vote.Percent != -10000 || vote.Reputation != 3117 {
+		t.Errorf("unexpected vote %+v", vote)
+	}
+}
+
+func TestDecodeWitness(t *testing.T) {
+	var w Witness
+	decodeResult(t, `{"jsonrpc":"2.0","id":1,"result":{
+		"owner":"steemit","votes":"27936735712341263",
+		"virtual_scheduled_time":"340282366920938463463374607431768211455",
+		"last_aslot":"20000000",
+		"props":{"account_creation_fee":{"amount":"3000","precision":3,"nai":"@@000000021"},"maximum_block_size":65536},
+		"sbd_exchange_rate":{"base":"0.250 SBD","quote":"1.000 STEEM"}}}`, &w)
+	if w.Props.AccountCreationFee != "3.000 STEEM" {
+		t.Errorf("expected %v, got %v", "3.000 STEEM", w.Props.AccountCreationFee)
+	}
+	if w.VirtualScheduledTime != "340282366920938463463374607431768211455" {
+		t.Errorf("unexpected virtual_scheduled_time %v", w.VirtualScheduledTime)
+	}
+	if w.LastAslot != 20000000 {
+		t.Errorf("expected %v, got %v", 20000000, w.LastAslot)
+	}
+	if w.SbdExchangeRate.Base != "0.250 SBD" {
+		t.Errorf("expected %v, got %v", "0.250 SBD", w.SbdExchangeRate.Base)
+	}
+}
+
+func TestDecodeVestingDelegations(t *testing.T) {
+	var delegations []VestingDelegation
+	decodeResult(t, `{"jsonrpc":"2.0","id":1,"result":[
+		{"delegator":"alice","delegatee":"bob","vesting_shares":{"amount":"1000000000","precision":6,"nai":"@@000000037"}}]}`, &delegations)
+	if len(delegations) != 1 || delegations[0].VestingShares != "1000.000000 VESTS" {
+		t.Errorf("unexpected delegations %+v", delegations)
+	}
+}
+
+func TestDecodeOpenOrders(t *testing.T) {
+	var orders []OpenOrder
+	decodeResult(t, `{"jsonrpc":"2.0","id":1,"result":[
+		{"seller":"alice","orderid":1700000000,"for_sale":"1000",
+		 "sell_price":{"base":"1.000 STEEM","quote":{"amount":"250","precision":3,"nai":"@@000000013"}}}]}`, &orders)
+	if len(orders) != 1 {
+		t.Fatalf("expected 1 order, got %d", len(orders))
+	}
+	order := orders[0]
+	if order.ForSale != 1000 || order.OrderId != 1700000000 {
+		t.Errorf("unexpected order %+v", order)
+	}
+	if order.SellPrice.Quote != "0.250 SBD" {
+		t.Errorf("expected %v, got %v", "0.250 SBD", order.SellPrice.Quote)
+	}
+}
+
+func TestDecodeFeedHistory(t *testing.T) {
+	var feed FeedHistory
+	decodeResult(t, `{"jsonrpc":"2.0","id":1,"result":{
+		"current_median_history":{"base":"0.250 SBD","quote":"1.000 STEEM"},
+		"price_history":[
+			{"base":"0.245 SBD","quote":"1.000 STEEM"},
+			{"base":{"amount":"255","precision":3,"nai":"@@000000013"},"quote":{"amount":"1000","precision":3,"nai":"@@000000021"}}]}}`, &feed)
+	if feed.CurrentMedianHistory.Base != "0.250 SBD" {
+		t.Errorf("expected %v, got %v", "0.250 SBD", feed.CurrentMedianHistory.Base)
+	}
+	if len(feed.PriceHistory) != 2 {
+		t.Fatalf("expected 2 prices, got %d", len(feed.PriceHistory))
+	}
+	if p := feed.PriceHistory[1]; p.Base != "0.255 SBD" || p.Quote != "1.000 STEEM" {
+		t.Errorf("unexpected price %+v", p)
+	}
+}
+
+func TestDecodeRewardFund(t *testing.T) {
+	var fund RewardFund
+	decodeResult(t, `{"jsonrpc":"2.0","id":1,"result":{
+		"name":"post","reward_balance":{"amount":"815310000","precision":3,"nai":"@@000000021"},
+		"recent_claims":"393542813012437462","content_constant":2000000000000}}`, &fund)
+	if fund.RewardBalance != "815310.000 STEEM" {
+		t.Errorf("expected %v, got %v", "815310.000 STEEM", fund.RewardBalance)
+	}
+	if fund.RecentClaims != "393542813012437462" {
+		t.Errorf("expected %v, got %v", "393542813012437462", fund.RecentClaims)
+	}
+	if fund.ContentConstant != "2000000000000" {
+		t.Errorf("expected %v, got %v", "2000000000000", fund.ContentConstant)
+	}
+}
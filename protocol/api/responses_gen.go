@@ -0,0 +1,226 @@
+// Code generated by apigen from api.MethodsData. DO NOT EDIT.
+
+package api
+
+import "encoding/json"
+
+// Responses of the methods of MethodsData. Methods without a typed model
+// return the raw result, which can be decoded later.
+type (
+	// SetSubscribeCallbackResponse is the result of database_api.set_subscribe_callback.
+	SetSubscribeCallbackResponse = json.RawMessage
+	// SetPendingTransactionCallbackResponse is the result of database_api.set_pending_transaction_callback.
+	SetPendingTransactionCallbackResponse = json.RawMessage
+	// SetBlockAppliedCallbackResponse is the result of database_api.set_block_applied_callback.
+	SetBlockAppliedCallbackResponse = json.RawMessage
+	// CancelAllSubscriptionsResponse is the result of database_api.cancel_all_subscriptions.
+	CancelAllSubscriptionsResponse = json.RawMessage
+	// GetTrendingTagsResponse is the result of database_api.get_trending_tags.
+	GetTrendingTagsResponse = json.RawMessage
+	// GetTagsUsedByAuthorResponse is the result of database_api.get_tags_used_by_author.
+	GetTagsUsedByAuthorResponse = json.RawMessage
+	// GetPostDiscussionsByPayoutResponse is the result of database_api.get_post_discussions_by_payout.
+	GetPostDiscussionsByPayoutResponse = []Content
+	// GetCommentDiscussionsByPayoutResponse is the result of database_api.get_comment_discussions_by_payout.
+	GetCommentDiscussionsByPayoutResponse = []Content
+	// GetDiscussionsByTrendingResponse is the result of database_api.get_discussions_by_trending.
+	GetDiscussionsByTrendingResponse = []Content
+	// GetDiscussionsByTrending30Response is the result of database_api.get_discussions_by_trending30.
+	GetDiscussionsByTrending30Response = []Content
+	// GetDiscussionsByCreatedResponse is the result of database_api.get_discussions_by_created.
+	GetDiscussionsByCreatedResponse = []Content
+	// GetDiscussionsByActiveResponse is the result of database_api.get_discussions_by_active.
+	GetDiscussionsByActiveResponse = []Content
+	// GetDiscussionsByCashoutResponse is the result of database_api.get_discussions_by_cashout.
+	GetDiscussionsByCashoutResponse = []Content
+	// GetDiscussionsByPayoutResponse is the result of database_api.get_discussions_by_payout.
+	GetDiscussionsByPayoutResponse = []Content
+	// GetDiscussionsByVotesResponse is the result of database_api.get_discussions_by_votes.
+	GetDiscussionsByVotesResponse = []Content
+	// GetDiscussionsByChildrenResponse is the result of database_api.get_discussions_by_children.
+	GetDiscussionsByChildrenResponse = []Content
+	// GetDiscussionsByHotResponse is the result of database_api.get_discussions_by_hot.
+	GetDiscussionsByHotResponse = []Content
+	// GetDiscussionsByFeedResponse is the result of database_api.get_discussions_by_feed.
+	GetDiscussionsByFeedResponse = []Content
+	// GetDiscussionsByBlogResponse is the result of database_api.get_discussions_by_blog.
+	GetDiscussionsByBlogResponse = []Content
+	// GetDiscussionsByCommentsResponse is the result of database_api.get_discussions_by_comments.
+	GetDiscussionsByCommentsResponse = []Content
+	// GetDiscussionsByPromotedResponse is the result of database_api.get_discussions_by_promoted.
+	GetDiscussionsByPromotedResponse = []Content
+	// GetBlockHeaderResponse is the result of database_api.get_block_header.
+	GetBlockHeaderResponse = json.RawMessage
+	// GetBlockResponse is the result of database_api.get_block.
+	GetBlockResponse = Block
+	// GetOpsInBlockResponse is the result of database_api.get_ops_in_block.
+	GetOpsInBlockResponse = json.RawMessage
+	// GetStateResponse is the result of database_api.get_state.
+	GetStateResponse = json.RawMessage
+	// GetTrendingCategoriesResponse is the result of database_api.get_trending_categories.
+	GetTrendingCategoriesResponse = json.RawMessage
+	// GetBestCategoriesResponse is the result of database_api.get_best_categories.
+	GetBestCategoriesResponse = json.RawMessage
+	// GetActiveCategoriesResponse is the result of database_api.get_active_categories.
+	GetActiveCategoriesResponse = json.RawMessage
+	// GetRecentCategoriesResponse is the result of database_api.get_recent_categories.
+	GetRecentCategoriesResponse = json.RawMessage
+	// GetConfigResponse is the result of database_api.get_config.
+	GetConfigResponse = json.RawMessage
+	// GetDynamicGlobalPropertiesResponse is the result of database_api.get_dynamic_global_properties.
+	GetDynamicGlobalPropertiesResponse = DynamicGlobalProperties
+	// GetChainPropertiesResponse is the result of database_api.get_chain_properties.
+	GetChainPropertiesResponse = json.RawMessage
+	// GetFeedHistoryResponse is the result of database_api.get_feed_history.
+	GetFeedHistoryResponse = FeedHistory
+	// GetCurrentMedianHistoryPriceResponse is the result of database_api.get_current_median_history_price.
+	GetCurrentMedianHistoryPriceResponse = Price
+	// GetWitnessScheduleResponse is the result of database_api.get_witness_schedule.
+	GetWitnessScheduleResponse = json.RawMessage
+	// GetHardforkVersionResponse is the result of database_api.get_hardfork_version.
+	GetHardforkVersionResponse = json.RawMessage
+	// GetNextScheduledHardforkResponse is the result of database_api.get_next_scheduled_hardfork.
+	GetNextScheduledHardforkResponse = json.RawMessage
+	// GetKeyReferencesResponse is the result of account_by_key_api.get_key_references.
+	GetKeyReferencesResponse = json.RawMessage
+	// GetAccountsResponse is the result of database_api.get_accounts.
+	GetAccountsResponse = []Account
+	// GetAccountReferencesResponse is the result of database_api.get_account_references.
+	GetAccountReferencesResponse = json.RawMessage
+	// LookupAccountNamesResponse is the result of database_api.lookup_account_names.
+	LookupAccountNamesResponse = []Account
+	// LookupAccountsResponse is the result of database_api.lookup_accounts.
+	LookupAccountsResponse = []string
+	// GetAccountCountResponse is the result of database_api.get_account_count.
+	GetAccountCountResponse = json.RawMessage
+	// GetConversionRequestsResponse is the result of database_api.get_conversion_requests.
+	GetConversionRequestsResponse = json.RawMessage
+	// GetAccountHistoryResponse is the result of database_api.get_account_history.
+	GetAccountHistoryResponse = json.RawMessage
+	// GetOwnerHistoryResponse is the result of database_api.get_owner_history.
+	GetOwnerHistoryResponse = json.RawMessage
+	// GetRecoveryRequestResponse is the result of database_api.get_recovery_request.
+	GetRecoveryRequestResponse = json.RawMessage
+	// GetEscrowResponse is the result of database_api.get_escrow.
+	GetEscrowResponse = json.RawMessage
+	// GetWithdrawRoutesResponse is the result of database_api.get_withdraw_routes.
+	GetWithdrawRoutesResponse = json.RawMessage
+	// GetAccountBandwidthResponse is the result of database_api.get_account_bandwidth.
+	GetAccountBandwidthResponse = json.RawMessage
+	// GetSavingsWithdrawFromResponse is the result of database_api.get_savings_withdraw_from.
+	GetSavingsWithdrawFromResponse = json.RawMessage
+	// GetSavingsWithdrawToResponse is the result of database_api.get_savings_withdraw_to.
+	GetSavingsWithdrawToResponse = json.RawMessage
+	// GetOrderBookResponse is the result of database_api.get_order_book.
+	GetOrderBookResponse = json.RawMessage
+	// GetOpenOrdersResponse is the result of database_api.get_open_orders.
+	GetOpenOrdersResponse = []OpenOrder
+	// GetLiquidityQueueResponse is the result of database_api.get_liquidity_queue.
+	GetLiquidityQueueResponse = json.RawMessage
+	// GetTransactionHexResponse is the result of database_api.get_transaction_hex.
+	GetTransactionHexResponse = json.RawMessage
+	// GetTransactionResponse is the result of database_api.get_transaction.
+	GetTransactionResponse = Transaction
+	// GetRequiredSignaturesResponse is the result of database_api.get_required_signatures.
+	GetRequiredSignaturesResponse = json.RawMessage
+	// GetPotentialSignaturesResponse is the result of database_api.get_potential_signatures.
+	GetPotentialSignaturesResponse = json.RawMessage
+	// VerifyAuthorityResponse is the result of database_api.verify_authority.
+	VerifyAuthorityResponse = json.RawMessage
+	// VerifyAccountAuthorityResponse is the result of database_api.verify_account_authority.
+	VerifyAccountAuthorityResponse = json.RawMessage
+	// GetActiveVotesResponse is the result of database_api.get_active_votes.
+	GetActiveVotesResponse = []VoteState
+	// GetAccountVotesResponse is the result of database_api.get_account_votes.
+	GetAccountVotesResponse = json.RawMessage
+	// GetContentResponse is the result of database_api.get_content.
+	GetContentResponse = Content
+	// GetContentRepliesResponse is the result of database_api.get_content_replies.
+	GetContentRepliesResponse = []Content
+	// GetDiscussionsByAuthorBeforeDateResponse is the result of database_api.get_discussions_by_author_before_date.
+	GetDiscussionsByAuthorBeforeDateResponse = []Content
+	// GetRepliesByLastUpdateResponse is the result of database_api.get_replies_by_last_update.
+	GetRepliesByLastUpdateResponse = []Content
+	// GetWitnessesResponse is the result of database_api.get_witnesses.
+	GetWitnessesResponse = []Witness
+	// GetWitnessByAccountResponse is the result of database_api.get_witness_by_account.
+	GetWitnessByAccountResponse = Witness
+	// GetWitnessesByVoteResponse is the result of database_api.get_witnesses_by_vote.
+	GetWitnessesByVoteResponse = []Witness
+	// LookupWitnessAccountsResponse is the result of database_api.lookup_witness_accounts.
+	LookupWitnessAccountsResponse = []string
+	// GetWitnessCountResponse is the result of database_api.get_witness_count.
+	GetWitnessCountResponse = json.RawMessage
+	// GetActiveWitnessesResponse is the result of database_api.get_active_witnesses.
+	GetActiveWitnessesResponse = []string
+	// GetMinerQueueResponse is the result of database_api.get_miner_queue.
+	GetMinerQueueResponse = json.RawMessage
+	// GetRewardFundResponse is the result of database_api.get_reward_fund.
+	GetRewardFundResponse = RewardFund
+	// GetVestingDelegationsResponse is the result of database_api.get_vesting_delegations.
+	GetVestingDelegationsResponse = []VestingDelegation
+	// LoginResponse is the result of login_api.login.
+	LoginResponse = json.RawMessage
+	// GetApiByNameResponse is the result of login_api.get_api_by_name.
+	GetApiByNameResponse = json.RawMessage
+	// GetVersionResponse is the result of login_api.get_version.
+	GetVersionResponse = json.RawMessage
+	// GetFollowersResponse is the result of follow_api.get_followers.
+	GetFollowersResponse = json.RawMessage
+	// GetFollowingResponse is the result of follow_api.get_following.
+	GetFollowingResponse = json.RawMessage
+	// GetFollowCountResponse is the result of follow_api.get_follow_count.
+	GetFollowCountResponse = json.RawMessage
+	// GetFeedEntriesResponse is the result of follow_api.get_feed_entries.
+	GetFeedEntriesResponse = json.RawMessage
+	// GetFeedResponse is the result of follow_api.get_feed.
+	GetFeedResponse = json.RawMessage
+	// GetBlogEntriesResponse is the result of follow_api.get_blog_entries.
+	GetBlogEntriesResponse = json.RawMessage
+	// GetBlogResponse is the result of follow_api.get_blog.
+	GetBlogResponse = json.RawMessage
+	// GetAccountReputationsResponse is the result of follow_api.get_account_reputations.
+	GetAccountReputationsResponse = json.RawMessage
+	// GetRebloggedByResponse is the result of follow_api.get_reblogged_by.
+	GetRebloggedByResponse = json.RawMessage
+	// GetBlogAuthorsResponse is the result of follow_api.get_blog_authors.
+	GetBlogAuthorsResponse = json.RawMessage
+	// BroadcastTransactionResponse is the result of network_broadcast_api.broadcast_transaction.
+	BroadcastTransactionResponse = json.RawMessage
+	// BroadcastTransactionWithCallbackResponse is the result of network_broadcast_api.broadcast_transaction_with_callback.
+	BroadcastTransactionWithCallbackResponse = json.RawMessage
+	// BroadcastTransactionSynchronousResponse is the result of network_broadcast_api.broadcast_transaction_synchronous.
+	BroadcastTransactionSynchronousResponse = json.RawMessage
+	// BroadcastBlockResponse is the result of network_broadcast_api.broadcast_block.
+	BroadcastBlockResponse = json.RawMessage
+	// SetMaxBlockAgeResponse is the result of network_broadcast_api.set_max_block_age.
+	SetMaxBlockAgeResponse = json.RawMessage
+	// GetTickerResponse is the result of market_history_api.get_ticker.
+	GetTickerResponse = json.RawMessage
+	// GetVolumeResponse is the result of market_history_api.get_volume.
+	GetVolumeResponse = json.RawMessage
+	// GetMarketOrderBookResponse is the result of market_history_api.get_order_book.
+	GetMarketOrderBookResponse = json.RawMessage
+	// GetTradeHistoryResponse is the result of market_history_api.get_trade_history.
+	GetTradeHistoryResponse = json.RawMessage
+	// GetRecentTradesResponse is the result of market_history_api.get_recent_trades.
+	GetRecentTradesResponse = json.RawMessage
+	// GetMarketHistoryResponse is the result of market_history_api.get_market_history.
+	GetMarketHistoryResponse = json.RawMessage
+	// GetMarketHistoryBucketsResponse is the result of market_history_api.get_market_history_buckets.
+	GetMarketHistoryBucketsResponse = json.RawMessage
+	// FindProposalsResponse is the result of condenser_api.find_proposals.
+	FindProposalsResponse = json.RawMessage
+	// ListProposalsResponse is the result of condenser_api.list_proposals.
+	ListProposalsResponse = json.RawMessage
+	// ListProposalVotesResponse is the result of condenser_api.list_proposal_votes.
+	ListProposalVotesResponse = json.RawMessage
+	// GetNaiPoolResponse is the result of condenser_api.get_nai_pool.
+	GetNaiPoolResponse = json.RawMessage
+	// FindRcAccountsResponse is the result of rc_api.find_rc_accounts.
+	FindRcAccountsResponse = RCAccounts
+	// GetExpiringVestingDelegationsResponse is the result of condenser_api.get_expiring_vesting_delegations.
+	GetExpiringVestingDelegationsResponse = json.RawMessage
+	// FindChangeRecoveryAccountRequestsResponse is the result of database_api.find_change_recovery_account_requests.
+	FindChangeRecoveryAccountRequestsResponse = json.RawMessage
+)
@@ -0,0 +1,36 @@
+package api
+
+import "github.com/steemit/steemutil/protocol"
+
+// WitnessProps are the chain properties a witness votes for.
+type WitnessProps struct {
+	AccountCreationFee   Asset           `json:"account_creation_fee"`
+	MaximumBlockSize     protocol.UInt32 `json:"maximum_block_size"`
+	SbdInterestRate      protocol.UInt16 `json:"sbd_interest_rate"`
+	AccountSubsidyBudget protocol.Int32  `json:"account_subsidy_budget"`
+	AccountSubsidyDecay  protocol.UInt32 `json:"account_subsidy_decay"`
+}
+
+// Witness is the result of condenser_api.get_witness_by_account.
+type Witness struct {
+	Id                    protocol.UInt   `json:"id"`
+	Owner                 string          `json:"owner"`
+	Created               *protocol.Time  `json:"created"`
+	URL                   string          `json:"url"`
+	Votes                 Number          `json:"votes"`
+	VirtualLastUpdate     Number          `json:"virtual_last_update"`
+	VirtualPosition       Number          `json:"virtual_position"`
+	VirtualScheduledTime  Number          `json:"virtual_scheduled_time"`
+	TotalMissed           protocol.UInt32 `json:"total_missed"`
+	LastAslot             protocol.UInt64 `json:"last_aslot"`
+	LastConfirmedBlockNum protocol.UInt64 `json:"last_confirmed_block_num"`
+	PowWorker             protocol.UInt64 `json:"pow_worker"`
+	SigningKey            string          `json:"signing_key"`
+	Props                 WitnessProps    `json:"props"`
+	SbdExchangeRate       Price           `json:"sbd_exchange_rate"`
+	LastSbdExchangeUpdate *protocol.Time  `json:"last_sbd_exchange_update"`
+	LastWork              string          `json:"last_work"`
+	RunningVersion        string          `json:"running_version"`
+	HardforkVersionVote   string          `json:"hardfork_version_vote"`
+	HardforkTimeVote      *protocol.Time  `json:"hardfork_time_vote"`
+}
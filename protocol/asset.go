@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/steemit/steemutil/consts"
 	"github.com/steemit/steemutil/encoder"
 
 	"github.com/pkg/errors"
@@ -60,6 +61,20 @@ func ParseAsset(assetStr string) (*Asset, error) {
 	}, nil
 }
 
+// ParseAssetWithConfig parses an asset string and checks that it is a core
+// asset of the given chain with the expected precision. ParseAsset accepts
+// any symbol and precision.
+func ParseAssetWithConfig(assetStr string, cfg *consts.ChainConfig) (*Asset, error) {
+	a, err := ParseAsset(assetStr)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.Validate(cfg); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
 // String returns the asset as a string like "0.001 STEEM".
 func (a *Asset) String() string {
 	if a == nil {
@@ -139,3 +154,56 @@ func UnmarshalAsset(data []byte) (*Asset, error) {
 	}, nil
 }
 
+// NAIAsset is an asset in the NAI format used by the appbase API, e.g.
+// {"amount":"1000","precision":3,"nai":"@@000000021"}.
+type NAIAsset struct {
+	Amount    string `json:"amount"`
+	Precision uint8  `json:"precision"`
+	NAI       string `json:"nai"`
+}
+
+// ToNAI converts the asset to the NAI format of the given chain.
+func (a *Asset) ToNAI(cfg *consts.ChainConfig) (*NAIAsset, error) {
+	if err := a.Validate(cfg); err != nil {
+		return nil, err
+	}
+	nai, _ := cfg.SymbolNAI(strings.ToUpper(a.Symbol))
+	return &NAIAsset{
+		Amount:    strconv.FormatInt(a.Amount, 10),
+		Precision: a.Precision,
+		NAI:       nai,
+	}, nil
+}
+
+// ToAsset converts the NAI asset to a legacy asset of the given chain.
+func (n *NAIAsset) ToAsset(cfg *consts.ChainConfig) (*Asset, error) {
+	symbol, ok := cfg.NAISymbol(n.NAI)
+	if !ok {
+		return nil, errors.Errorf("unknown nai: %s", n.NAI)
+	}
+	amount, err := strconv.ParseInt(n.Amount, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse amount: %s", n.Amount)
+	}
+	a := &Asset{Amount: amount, Precision: n.Precision, Symbol: symbol}
+	if err := a.Validate(cfg); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Validate checks that the asset is a core asset of the given chain with
+// the expected precision.
+func (a *Asset) Validate(cfg *consts.ChainConfig) error {
+	if a == nil {
+		return errors.New("asset is nil")
+	}
+	precision, ok := cfg.SymbolPrecision(strings.ToUpper(a.Symbol))
+	if !ok {
+		return errors.Errorf("unknown asset symbol on %s: %s", cfg.Name, a.Symbol)
+	}
+	if a.Precision != precision {
+		return errors.Errorf("invalid precision for %s: expected %d, got %d", a.Symbol, precision, a.Precision)
+	}
+	return nil
+}
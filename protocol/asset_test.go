@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"testing"
 
+	"github.com/steemit/steemutil/consts"
 	"github.com/steemit/steemutil/encoder"
 )
 
@@ -132,3 +133,77 @@ func TestAsset_String(t *testing.T) {
 		}
 	}
 }
+
+func TestAsset_NAI(t *testing.T) {
+	asset, err := ParseAsset("1.000 TESTS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nai, err := asset.ToNAI(consts.SteemTestnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := NAIAsset{Amount: "1000", Precision: 3, NAI: "@@000000021"}
+	if *nai != expected {
+		t.Errorf("expected %v, got %v", expected, *nai)
+	}
+
+	back, err := nai.ToAsset(consts.SteemTestnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.String() != "1.000 TESTS" {
+		t.Errorf("expected 1.000 TESTS, got %v", back.String())
+	}
+
+	if _, err := asset.ToNAI(consts.SteemMainnet); err == nil {
+		t.Error("expected an error for TESTS on mainnet")
+	}
+}
+
+func TestAsset_Validate(t *testing.T) {
+	testCases := []struct {
+		input string
+		valid bool
+	}{
+		{"1.000 STEEM", true},
+		{"1.000000 VESTS", true},
+		{"1.00 SBD", false},
+		{"1.000 FOO", false},
+	}
+
+	for _, tc := range testCases {
+		asset, err := ParseAsset(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := asset.Validate(consts.SteemMainnet); (err == nil) != tc.valid {
+			t.Errorf("for input %s, expected valid %v, got %v", tc.input, tc.valid, err)
+		}
+	}
+}
+
+func TestParseAssetWithConfig(t *testing.T) {
+	testCases := []struct {
+		input string
+		cfg   *consts.ChainConfig
+		valid bool
+	}{
+		{"1.000 STEEM", consts.SteemMainnet, true},
+		{"1.000 TESTS", consts.SteemTestnet, true},
+		{"1.000 TESTS", consts.SteemMainnet, false},
+		{"1.000 STEEM", consts.SteemTestnet, false},
+		{"1.00 SBD", consts.SteemMainnet, false},
+		{"1.000", consts.SteemMainnet, false},
+	}
+
+	for _, tc := range testCases {
+		asset, err := ParseAssetWithConfig(tc.input, tc.cfg)
+		if (err == nil) != tc.valid {
+			t.Errorf("for input %s on %s, expected valid %v, got %v", tc.input, tc.cfg.Name, tc.valid, err)
+		}
+		if err == nil && asset.String() != tc.input {
+			t.Errorf("expected %s, got %s", tc.input, asset.String())
+		}
+	}
+}
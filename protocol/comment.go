@@ -0,0 +1,314 @@
+package protocol
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/consts"
+)
+
+// Values of CommentMetadata.Format.
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// DefaultMaxAcceptedPayout is the max_accepted_payout of comments without
+// comment_options.
+const DefaultMaxAcceptedPayout = "1000000.000 SBD"
+
+// permlinkSuffixLength is the number of random characters NewPermlink adds.
+const permlinkSuffixLength = 8
+
+// replyTimestamp matches the timestamp ReplyPermlink appends, so replies to
+// replies do not pile them up.
+var replyTimestamp = regexp.MustCompile(`-\d{8}t\d{9}z`)
+
+// IsCanonicalPermlink reports whether permlink only uses the charset Steem
+// front-ends create permlinks with: lowercase letters, digits and dashes.
+// The chain itself accepts any valid UTF-8 permlink.
+func IsCanonicalPermlink(permlink string) bool {
+	if permlink == "" || len(permlink) >= consts.SteemMainnet.MaxPermlinkLength {
+		return false
+	}
+	for _, c := range permlink {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// PermlinkFromTitle turns a title into a permlink: lowercase letters, digits
+// and single dashes. Other characters are dropped or become dashes. The
+// result may be empty, e.g. for titles without ASCII letters.
+func PermlinkFromTitle(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+		case r == '\'' || r == '"' || r == '`' || r >= 0x80:
+			// Dropped without separating words, e.g. "don't" -> "dont".
+		default:
+			dash = true
+		}
+	}
+	permlink := b.String()
+	if max := consts.SteemMainnet.MaxPermlinkLength - 1; len(permlink) > max {
+		permlink = strings.TrimRight(permlink[:max], "-")
+	}
+	return permlink
+}
+
+// NewPermlink returns PermlinkFromTitle(title) followed by a dash and random
+// characters, so that posts with the same title get distinct permlinks.
+func NewPermlink(title string) (string, error) {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+	suffix := make([]byte, permlinkSuffixLength)
+	for i := range suffix {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(alphabet))))
+		if err != nil {
+			return "", errors.Wrap(err, "failed to generate permlink")
+		}
+		suffix[i] = alphabet[n.Int64()]
+	}
+
+	slug := PermlinkFromTitle(title)
+	if slug == "" {
+		return string(suffix), nil
+	}
+	if max := consts.SteemMainnet.MaxPermlinkLength - 2 - permlinkSuffixLength; len(slug) > max {
+		slug = strings.TrimRight(slug[:max], "-")
+	}
+	return slug + "-" + string(suffix), nil
+}
+
+// ReplyPermlink returns the permlink condenser gives a reply:
+// re-<parent author>-<parent permlink>-<timestamp>, keeping the last 255
+// characters.
+func ReplyPermlink(parentAuthor, parentPermlink string, now time.Time) string {
+	now = now.UTC()
+	timestamp := now.Format("20060102t150405") + fmt.Sprintf("%03dz", now.Nanosecond()/int(time.Millisecond))
+	parentPermlink = replyTimestamp.ReplaceAllString(parentPermlink, "")
+
+	permlink := strings.ToLower("re-" + parentAuthor + "-" + parentPermlink + "-" + timestamp)
+	if max := consts.SteemMainnet.MaxPermlinkLength - 1; len(permlink) > max {
+		permlink = permlink[len(permlink)-max:]
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' {
+			return r
+		}
+		return -1
+	}, permlink)
+}
+
+// CommentMetadata is the json_metadata of a comment as written by condenser
+// and most other front-ends.
+type CommentMetadata struct {
+	Tags   []string `json:"tags,omitempty"`
+	App    string   `json:"app,omitempty"`
+	Format string   `json:"format,omitempty"`
+	Image  []string `json:"image,omitempty"`
+	Links  []string `json:"links,omitempty"`
+}
+
+// NewCommentMetadata creates markdown metadata for the given app, e.g.
+// "myapp/1.0".
+func NewCommentMetadata(app string) *CommentMetadata {
+	return &CommentMetadata{App: app, Format: FormatMarkdown}
+}
+
+// AddTags appends tags, lowercased and without a leading '#'. Empty and
+// duplicate tags are skipped.
+func (m *CommentMetadata) AddTags(tags ...string) *CommentMetadata {
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		if tag != "" && !containsString(m.Tags, tag) {
+			m.Tags = append(m.Tags, tag)
+		}
+	}
+	return m
+}
+
+// AddImages appends image URLs, skipping duplicates.
+func (m *CommentMetadata) AddImages(urls ...string) *CommentMetadata {
+	for _, url := range urls {
+		if url != "" && !containsString(m.Image, url) {
+			m.Image = append(m.Image, url)
+		}
+	}
+	return m
+}
+
+// AddLinks appends link URLs, skipping duplicates.
+func (m *CommentMetadata) AddLinks(urls ...string) *CommentMetadata {
+	for _, url := range urls {
+		if url != "" && !containsString(m.Links, url) {
+			m.Links = append(m.Links, url)
+		}
+	}
+	return m
+}
+
+// JSON returns the metadata as a json_metadata string.
+func (m *CommentMetadata) JSON() (string, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal json_metadata")
+	}
+	return string(data), nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// CommentBuilder collects the fields of a post or reply. Build turns it into
+// a comment operation and, when any payout option differs from the chain
+// defaults, a comment_options operation.
+type CommentBuilder struct {
+	ParentAuthor   string
+	ParentPermlink string
+	Author         string
+	// Permlink is generated by Build when empty: from the title for posts,
+	// with ReplyPermlink for replies.
+	Permlink string
+	Title    string
+	Body     string
+	Metadata *CommentMetadata
+
+	MaxAcceptedPayout    string
+	PercentSteemDollars  uint16
+	AllowVotes           bool
+	AllowCurationRewards bool
+	Beneficiaries        []Beneficiary
+}
+
+// NewPostBuilder starts a root post. The first tag is its category, used as
+// the parent permlink.
+func NewPostBuilder(author, title, body string, tags ...string) *CommentBuilder {
+	metadata := NewCommentMetadata("").AddTags(tags...)
+	b := newCommentBuilder(author, body, metadata)
+	b.Title = title
+	if len(metadata.Tags) > 0 {
+		b.ParentPermlink = metadata.Tags[0]
+	}
+	return b
+}
+
+// NewReplyBuilder starts a reply to the comment of parentAuthor.
+func NewReplyBuilder(author, parentAuthor, parentPermlink, body string) *CommentBuilder {
+	b := newCommentBuilder(author, body, NewCommentMetadata(""))
+	b.ParentAuthor = parentAuthor
+	b.ParentPermlink = parentPermlink
+	return b
+}
+
+func newCommentBuilder(author, body string, metadata *CommentMetadata) *CommentBuilder {
+	return &CommentBuilder{
+		Author:               author,
+		Body:                 body,
+		Metadata:             metadata,
+		MaxAcceptedPayout:    DefaultMaxAcceptedPayout,
+		PercentSteemDollars:  Percent100,
+		AllowVotes:           true,
+		AllowCurationRewards: true,
+	}
+}
+
+// DeclinePayout sets the max accepted payout to zero.
+func (b *CommentBuilder) DeclinePayout() *CommentBuilder {
+	b.MaxAcceptedPayout = "0.000 SBD"
+	return b
+}
+
+// AddBeneficiary routes weight basis points of the author reward to account.
+func (b *CommentBuilder) AddBeneficiary(account string, weight uint16) *CommentBuilder {
+	b.Beneficiaries = append(b.Beneficiaries, Beneficiary{Account: account, Weight: weight})
+	return b
+}
+
+// Build validates and returns the comment operation and the comment_options
+// operation, which is nil when the defaults apply. Both must be broadcast in
+// the same transaction, comment first.
+func (b *CommentBuilder) Build() (*CommentOperation, *CommentOptionsOperation, error) {
+	if b.ParentPermlink == "" {
+		if b.ParentAuthor == "" {
+			return nil, nil, errors.New("a post needs at least one tag")
+		}
+		return nil, nil, errors.New("a reply needs the parent permlink")
+	}
+
+	permlink := b.Permlink
+	if permlink == "" {
+		if b.ParentAuthor == "" {
+			var err error
+			if permlink, err = NewPermlink(b.Title); err != nil {
+				return nil, nil, err
+			}
+		} else {
+			permlink = ReplyPermlink(b.ParentAuthor, b.ParentPermlink, time.Now())
+		}
+	}
+
+	var metadata string
+	if b.Metadata != nil {
+		var err error
+		if metadata, err = b.Metadata.JSON(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	comment := &CommentOperation{
+		ParentAuthor:   b.ParentAuthor,
+		ParentPermlink: b.ParentPermlink,
+		Author:         b.Author,
+		Permlink:       permlink,
+		Title:          b.Title,
+		Body:           b.Body,
+		JsonMetadata:   metadata,
+	}
+	if err := comment.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if b.MaxAcceptedPayout == DefaultMaxAcceptedPayout && b.PercentSteemDollars == Percent100 &&
+		b.AllowVotes && b.AllowCurationRewards && len(b.Beneficiaries) == 0 {
+		return comment, nil, nil
+	}
+
+	options := &CommentOptionsOperation{
+		Author:               b.Author,
+		Permlink:             permlink,
+		MaxAcceptedPayout:    b.MaxAcceptedPayout,
+		PercentSteemDollars:  b.PercentSteemDollars,
+		AllowVotes:           b.AllowVotes,
+		AllowCurationRewards: b.AllowCurationRewards,
+		Extensions:           []any{},
+	}
+	if len(b.Beneficiaries) > 0 {
+		options.Extensions = []any{NewBeneficiariesExtension(b.Beneficiaries)}
+	}
+	if err := options.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return comment, options, nil
+}
@@ -0,0 +1,259 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steemit/steemutil/encoder"
+)
+
+func TestPermlinkFromTitle(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{"Hello World", "hello-world"},
+		{"  Don't Panic!  ", "dont-panic"},
+		{"Steem & Go: a guide (part 2)", "steem-go-a-guide-part-2"},
+		{"Café", "caf"},
+		{"日本語", ""},
+		{strings.Repeat("a ", 200), strings.TrimRight(strings.Repeat("a-", 128)[:255], "-")},
+	}
+	for _, tt := range tests {
+		if got := PermlinkFromTitle(tt.title); got != tt.expected {
+			t.Errorf("%q: expected %v, got %v", tt.title, tt.expected, got)
+		}
+	}
+}
+
+func TestIsCanonicalPermlink(t *testing.T) {
+	tests := map[string]bool{
+		"hello-world":             true,
+		"re-bob-20240101t000000z": true,
+		"My_Post":                 false,
+		"한국어":                     false,
+		"":                        false,
+		strings.Repeat("a", 256):  false,
+	}
+	for permlink, expected := range tests {
+		if got := IsCanonicalPermlink(permlink); got != expected {
+			t.Errorf("%q: expected %v, got %v", permlink, expected, got)
+		}
+	}
+}
+
+func TestNewPermlink(t *testing.T) {
+	first, err := NewPermlink("Hello World")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := NewPermlink("Hello World")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`^hello-world-[a-z0-9]{8}$`).MatchString(first) {
+		t.Errorf("unexpected permlink %v", first)
+	}
+	if first == second {
+		t.Errorf("expected distinct permlinks, got %v twice", first)
+	}
+
+	untitled, err := NewPermlink("日本語")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(untitled) != permlinkSuffixLength {
+		t.Errorf("expected a random permlink, got %v", untitled)
+	}
+	long, err := NewPermlink(strings.Repeat("word ", 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(long) >= 256 {
+		t.Errorf("expected a permlink shorter than 256 bytes, got %d", len(long))
+	}
+}
+
+func TestReplyPermlink(t *testing.T) {
+	now := time.Date(2024, 3, 5, 7, 8, 9, 123456789, time.UTC)
+	got := ReplyPermlink("Bob", "my-post", now)
+	if expected := "re-bob-my-post-20240305t070809123z"; got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	// Replies to replies drop the parent timestamp.
+	got = ReplyPermlink("alice", got, now.Add(time.Second))
+	if expected := "re-alice-re-bob-my-post-20240305t070810123z"; got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	got = ReplyPermlink("alice", strings.Repeat("x", 300), now)
+	if len(got) != 255 || !strings.HasSuffix(got, "-20240305t070809123z") {
+		t.Errorf("expected the last 255 characters, got %v", got)
+	}
+}
+
+func TestCommentMetadata(t *testing.T) {
+	m := NewCommentMetadata("myapp/1.0").
+		AddTags("Steem", "#golang", "steem", " ").
+		AddImages("https://example.com/a.png", "https://example.com/a.png").
+		AddLinks("https://example.com")
+	got, err := m.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"tags":["steem","golang"],"app":"myapp/1.0","format":"markdown","image":["https://example.com/a.png"],"links":["https://example.com"]}`
+	if got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestCommentBuilderPost(t *testing.T) {
+	b := NewPostBuilder("alice", "Hello World", "body", "Steem", "go")
+	b.Metadata.App = "myapp/1.0"
+	comment, options, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if options != nil {
+		t.Errorf("expected no comment_options, got %+v", options)
+	}
+	if comment.ParentAuthor != "" || comment.ParentPermlink != "steem" {
+		t.Errorf("expected category steem, got %v/%v", comment.ParentAuthor, comment.ParentPermlink)
+	}
+	if !strings.HasPrefix(comment.Permlink, "hello-world-") {
+		t.Errorf("unexpected permlink %v", comment.Permlink)
+	}
+	var metadata CommentMetadata
+	if err := json.Unmarshal([]byte(comment.JsonMetadata), &metadata); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(metadata.Tags, ",") != "steem,go" || metadata.App != "myapp/1.0" {
+		t.Errorf("unexpected metadata %v", comment.JsonMetadata)
+	}
+
+	if _, _, err := NewPostBuilder("alice", "title", "body").Build(); err == nil {
+		t.Error("expected an error for a post without tags")
+	}
+	if _, _, err := NewPostBuilder("Alice", "title", "body", "steem").Build(); err == nil {
+		t.Error("expected an error for an invalid author")
+	}
+	_, _, err = NewReplyBuilder("alice", "bob", "", "body").Build()
+	if err == nil || err.Error() != "a reply needs the parent permlink" {
+		t.Errorf("expected a missing parent permlink error, got %v", err)
+	}
+}
+
+func TestCommentBuilderReplyOptions(t *testing.T) {
+	b := NewReplyBuilder("alice", "bob", "my-post", "nice")
+	b.Permlink = "p"
+	b.AddBeneficiary("dave", 500).AddBeneficiary("carol", 1000)
+	b.PercentSteemDollars = 0
+	comment, options, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment.ParentAuthor != "bob" || comment.Permlink != "p" {
+		t.Errorf("unexpected comment %+v", comment)
+	}
+	if options == nil {
+		t.Fatal("expected comment_options")
+	}
+	beneficiaries, err := options.Beneficiaries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(beneficiaries) != 2 || beneficiaries[0].Account != "carol" || beneficiaries[1].Account != "dave" {
+		t.Errorf("expected sorted beneficiaries, got %v", beneficiaries)
+	}
+
+	data, err := json.Marshal(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"author":"alice","permlink":"p","max_accepted_payout":"1000000.000 SBD","percent_steem_dollars":0,"allow_votes":true,"allow_curation_rewards":true,"extensions":[[0,{"beneficiaries":[{"account":"carol","weight":1000},{"account":"dave","weight":500}]}]]}`
+	if string(data) != expected {
+		t.Errorf("expected %v, got %v", expected, string(data))
+	}
+
+	b.AddBeneficiary("erin", 9000)
+	if _, _, err := b.Build(); err == nil {
+		t.Error("expected an error for beneficiaries above 100%")
+	}
+
+	// The builder keeps the order beneficiaries were added in.
+	if b.Beneficiaries[0].Account != "dave" {
+		t.Errorf("expected builder beneficiaries to be left unsorted, got %v", b.Beneficiaries)
+	}
+	dup := NewReplyBuilder("alice", "bob", "my-post", "nice")
+	dup.AddBeneficiary("dave", 500).AddBeneficiary("carol", 1000).AddBeneficiary("dave", 100)
+	if _, _, err := dup.Build(); err == nil {
+		t.Error("expected an error for a duplicate beneficiary")
+	}
+}
+
+func TestCommentBuilderReplyLegacyPermlink(t *testing.T) {
+	// Old posts may have permlinks front-ends no longer produce.
+	for _, parent := range []string{"My_Legacy_Post", "Re-Bob-Post", "한국어"} {
+		b := NewReplyBuilder("alice", "bob", parent, "nice")
+		b.AddBeneficiary("dave", 500).AddBeneficiary("carol", 1000)
+		comment, options, err := b.Build()
+		if err != nil {
+			t.Errorf("%q: %v", parent, err)
+			continue
+		}
+		if comment.ParentPermlink != parent {
+			t.Errorf("expected parent permlink %v, got %v", parent, comment.ParentPermlink)
+		}
+		if !IsCanonicalPermlink(comment.Permlink) {
+			t.Errorf("expected a canonical reply permlink, got %v", comment.Permlink)
+		}
+		if options == nil || options.Permlink != comment.Permlink {
+			t.Errorf("expected comment_options for %v, got %+v", comment.Permlink, options)
+		}
+	}
+}
+
+func TestCommentOptionsOperation_MarshalTransaction(t *testing.T) {
+	op := &CommentOptionsOperation{
+		Author:               "alice",
+		Permlink:             "p",
+		MaxAcceptedPayout:    DefaultMaxAcceptedPayout,
+		PercentSteemDollars:  10000,
+		AllowVotes:           true,
+		AllowCurationRewards: true,
+		Extensions:           []any{NewBeneficiariesExtension([]Beneficiary{{Account: "bob", Weight: 1000}})},
+	}
+	expected := "13" + "05616c696365" + "0170" + "00ca9a3b00000000" + "03" + "53424400000000" +
+		"1027" + "01" + "01" + "01" + "00" + "01" + "03626f62" + "e803"
+
+	// The same operation decoded from JSON must encode identically.
+	data, err := json.Marshal(op)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded CommentOptionsOperation
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, o := range []*CommentOptionsOperation{op, &decoded} {
+		var buf bytes.Buffer
+		if err := encoder.NewEncoder(&buf).Encode(o); err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(buf.Bytes()); got != expected {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	}
+
+	op.Extensions = []any{[]any{1, map[string]any{}}}
+	if err := encoder.NewEncoder(&bytes.Buffer{}).Encode(op); err == nil {
+		t.Error("expected an error for an unsupported extension")
+	}
+}
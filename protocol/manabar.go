@@ -0,0 +1,279 @@
+package protocol
+
+import (
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/consts"
+)
+
+// FC_REFLECT( steem::chain::util::manabar,
+//             (current_mana)
+//             (last_update_time) )
+
+// Manabar represents a regenerating mana pool such as an account's
+// voting_manabar, downvote_manabar or rc_manabar.
+type Manabar struct {
+	CurrentMana    Int64  `json:"current_mana"`
+	LastUpdateTime UInt32 `json:"last_update_time"`
+}
+
+// ManabarParams holds the parameters a manabar regenerates with.
+type ManabarParams struct {
+	MaxMana   int64
+	RegenTime int64
+}
+
+// Regenerate returns the manabar as it would be at the given time.
+//
+// This mirrors util::manabar::regenerate_mana in steemd: mana grows linearly
+// from zero to MaxMana over RegenTime seconds and is capped at MaxMana.
+// Like steemd, at most RegenTime seconds are counted, so a negative manabar
+// ends short of MaxMana by its deficit.
+func (m Manabar) Regenerate(params ManabarParams, now time.Time) Manabar {
+	ts := now.Unix()
+	if ts <= int64(m.LastUpdateTime) || params.RegenTime <= 0 {
+		return m
+	}
+	dt := ts - int64(m.LastUpdateTime)
+	if dt > params.RegenTime {
+		dt = params.RegenTime
+	}
+
+	current := int64(m.CurrentMana)
+	if current >= params.MaxMana {
+		current = params.MaxMana
+	} else {
+		maxMana := params.MaxMana
+		if maxMana < 0 {
+			maxMana = 0
+		}
+
+		// u_regen = max_mana * dt / regen_time, computed in 128 bits.
+		regen := new(big.Int).Mul(big.NewInt(maxMana), big.NewInt(dt))
+		regen.Quo(regen, big.NewInt(params.RegenTime))
+
+		next := regen.Add(regen, big.NewInt(current))
+		if next.Cmp(big.NewInt(params.MaxMana)) > 0 {
+			current = params.MaxMana
+		} else {
+			current = next.Int64()
+		}
+	}
+
+	return Manabar{
+		CurrentMana:    Int64(current),
+		LastUpdateTime: UInt32(ts),
+	}
+}
+
+// HasMana reports whether the manabar holds at least the given amount of mana.
+func (m Manabar) HasMana(needed int64) bool {
+	return needed <= 0 || int64(m.CurrentMana) >= needed
+}
+
+// UseMana subtracts the given amount of mana, saturating at math.MinInt64.
+func (m *Manabar) UseMana(used int64) {
+	current := int64(m.CurrentMana)
+	if used > 0 && current < math.MinInt64+used {
+		m.CurrentMana = math.MinInt64
+		return
+	}
+	m.CurrentMana = Int64(current - used)
+}
+
+// Percent returns the current mana as a fraction of MaxMana,
+// expressed in basis points (consts.STEEM_100_PERCENT is full).
+func (m Manabar) Percent(params ManabarParams) int64 {
+	if params.MaxMana <= 0 {
+		return 0
+	}
+	pct := new(big.Int).Mul(big.NewInt(int64(m.CurrentMana)), big.NewInt(consts.STEEM_100_PERCENT))
+	pct.Quo(pct, big.NewInt(params.MaxMana))
+	return pct.Int64()
+}
+
+// TimeToFull returns how long the manabar needs to regenerate to MaxMana,
+// counting from its LastUpdateTime.
+func (m Manabar) TimeToFull(params ManabarParams) time.Duration {
+	missing := params.MaxMana - int64(m.CurrentMana)
+	if missing <= 0 || params.MaxMana <= 0 {
+		return 0
+	}
+
+	// Round up so that regenerating for the returned duration fills the bar.
+	secs := new(big.Int).Mul(big.NewInt(missing), big.NewInt(params.RegenTime))
+	secs.Add(secs, big.NewInt(params.MaxMana-1))
+	secs.Quo(secs, big.NewInt(params.MaxMana))
+	return time.Duration(secs.Int64()) * time.Second
+}
+
+// VotingMana calculates an account's voting and downvote mana together with
+// the rshares its votes produce, using the same integer math as steemd.
+type VotingMana struct {
+	VotingManabar   Manabar
+	DownvoteManabar Manabar
+
+	// EffectiveVestingShares is vesting_shares - delegated_vesting_shares +
+	// received_vesting_shares minus the pending power down, in raw VESTS
+	// units (precision 6).
+	EffectiveVestingShares int64
+
+	// VotePowerReserveRate and DownvotePoolPercent come from the dynamic
+	// global properties. NewVotingMana fills in the current chain defaults.
+	VotePowerReserveRate int64
+	DownvotePoolPercent  int64
+}
+
+// NewVotingMana creates a VotingMana from an account's manabars and vesting
+// figures as returned by get_accounts, e.g. "1234.567890 VESTS". toWithdraw
+// and withdrawn are the raw to_withdraw and withdrawn amounts.
+func NewVotingMana(votingManabar, downvoteManabar Manabar, vestingShares, delegatedVestingShares, receivedVestingShares, vestingWithdrawRate string, toWithdraw, withdrawn int64) (*VotingMana, error) {
+	vests, err := EffectiveVestingShares(vestingShares, delegatedVestingShares, receivedVestingShares, vestingWithdrawRate, toWithdraw, withdrawn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VotingMana{
+		VotingManabar:          votingManabar,
+		DownvoteManabar:        downvoteManabar,
+		EffectiveVestingShares: vests,
+		VotePowerReserveRate:   consts.STEEM_DEFAULT_VOTE_POWER_RESERVE_RATE,
+		DownvotePoolPercent:    consts.STEEM_DEFAULT_DOWNVOTE_POOL_PERCENT,
+	}, nil
+}
+
+// EffectiveVestingShares returns vesting - delegated + received in raw VESTS
+// units, less the pending power down min(vesting_withdraw_rate, to_withdraw -
+// withdrawn) like steemd's get_effective_vesting_shares.
+func EffectiveVestingShares(vestingShares, delegatedVestingShares, receivedVestingShares, vestingWithdrawRate string, toWithdraw, withdrawn int64) (int64, error) {
+	var amounts [4]int64
+	for i, s := range []string{vestingShares, delegatedVestingShares, receivedVestingShares, vestingWithdrawRate} {
+		if s == "" {
+			continue
+		}
+		asset, err := ParseAsset(s)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to parse vesting figure: %s", s)
+		}
+		if asset.Symbol != "VESTS" {
+			return 0, errors.Errorf("expected VESTS, got %s", asset.Symbol)
+		}
+		amounts[i] = asset.Amount
+	}
+
+	powerDown := toWithdraw - withdrawn
+	if amounts[3] < powerDown {
+		powerDown = amounts[3]
+	}
+	if powerDown < 0 {
+		powerDown = 0
+	}
+	return amounts[0] - amounts[1] + amounts[2] - powerDown, nil
+}
+
+// VotingParams returns the manabar parameters of the voting manabar.
+func (vm *VotingMana) VotingParams() ManabarParams {
+	return ManabarParams{
+		MaxMana:   vm.EffectiveVestingShares,
+		RegenTime: consts.STEEM_VOTING_MANA_REGENERATION_SECONDS,
+	}
+}
+
+// DownvoteParams returns the manabar parameters of the downvote manabar.
+func (vm *VotingMana) DownvoteParams() ManabarParams {
+	maxMana := new(big.Int).Mul(big.NewInt(vm.EffectiveVestingShares), big.NewInt(vm.DownvotePoolPercent))
+	maxMana.Quo(maxMana, big.NewInt(consts.STEEM_100_PERCENT))
+	return ManabarParams{
+		MaxMana:   maxMana.Int64(),
+		RegenTime: consts.STEEM_VOTING_MANA_REGENERATION_SECONDS,
+	}
+}
+
+// Current returns the voting manabar regenerated up to now.
+func (vm *VotingMana) Current(now time.Time) Manabar {
+	return vm.VotingManabar.Regenerate(vm.VotingParams(), now)
+}
+
+// CurrentDownvote returns the downvote manabar regenerated up to now.
+func (vm *VotingMana) CurrentDownvote(now time.Time) Manabar {
+	return vm.DownvoteManabar.Regenerate(vm.DownvoteParams(), now)
+}
+
+// VotingPower returns the current voting mana in basis points.
+func (vm *VotingMana) VotingPower(now time.Time) int64 {
+	return vm.Current(now).Percent(vm.VotingParams())
+}
+
+// DownvotePower returns the current downvote mana in basis points.
+func (vm *VotingMana) DownvotePower(now time.Time) int64 {
+	return vm.CurrentDownvote(now).Percent(vm.DownvoteParams())
+}
+
+// TimeToFull returns how long until the voting manabar is full again.
+func (vm *VotingMana) TimeToFull(now time.Time) time.Duration {
+	return vm.Current(now).TimeToFull(vm.VotingParams())
+}
+
+// DownvoteTimeToFull returns how long until the downvote manabar is full again.
+func (vm *VotingMana) DownvoteTimeToFull(now time.Time) time.Duration {
+	return vm.CurrentDownvote(now).TimeToFull(vm.DownvoteParams())
+}
+
+// UsedMana returns the mana a vote with the given weight consumes at the given time.
+//
+// Following the vote evaluator, the amount is derived from the current voting
+// mana. Since HF21 downvotes use max(downvote_mana * STEEM_100_PERCENT /
+// downvote_pool_percent, voting_mana) instead.
+func (vm *VotingMana) UsedMana(weight Int16, now time.Time) int64 {
+	absWeight := int64(weight)
+	if absWeight < 0 {
+		absWeight = -absWeight
+	}
+	if absWeight > consts.STEEM_100_PERCENT {
+		absWeight = consts.STEEM_100_PERCENT
+	}
+
+	maxVoteDenom := vm.VotePowerReserveRate * consts.STEEM_VOTING_MANA_REGENERATION_SECONDS
+	if maxVoteDenom <= 0 {
+		return 0
+	}
+
+	current := big.NewInt(int64(vm.Current(now).CurrentMana))
+	if weight < 0 && vm.DownvotePoolPercent > 0 {
+		downvote := big.NewInt(int64(vm.CurrentDownvote(now).CurrentMana))
+		downvote.Mul(downvote, big.NewInt(consts.STEEM_100_PERCENT))
+		downvote.Quo(downvote, big.NewInt(vm.DownvotePoolPercent))
+		if downvote.Cmp(current) > 0 {
+			current = downvote
+		}
+	}
+	if current.Sign() <= 0 {
+		return 0
+	}
+
+	// used_mana = current_mana * abs_weight * 60*60*24 / STEEM_100_PERCENT
+	used := new(big.Int).Mul(current, big.NewInt(absWeight))
+	used.Mul(used, big.NewInt(60*60*24))
+	used.Quo(used, big.NewInt(consts.STEEM_100_PERCENT))
+
+	// used_mana = ( used_mana + max_vote_denom - 1 ) / max_vote_denom
+	used.Add(used, big.NewInt(maxVoteDenom-1))
+	used.Quo(used, big.NewInt(maxVoteDenom))
+	return used.Int64()
+}
+
+// RShares returns the rshares a vote with the given weight would produce at
+// the given time. The result is negative for downvotes.
+func (vm *VotingMana) RShares(weight Int16, now time.Time) int64 {
+	rshares := vm.UsedMana(weight, now) - consts.STEEM_VOTE_DUST_THRESHOLD
+	if rshares < 0 {
+		rshares = 0
+	}
+	if weight < 0 {
+		return -rshares
+	}
+	return rshares
+}
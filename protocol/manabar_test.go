@@ -0,0 +1,220 @@
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestManabar_UnmarshalJSON(t *testing.T) {
+	var m Manabar
+	data := `{"current_mana":"98765432100","last_update_time":1600000000}`
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if m.CurrentMana != 98765432100 {
+		t.Errorf("expected current_mana 98765432100, got %d", m.CurrentMana)
+	}
+	if m.LastUpdateTime != 1600000000 {
+		t.Errorf("expected last_update_time 1600000000, got %d", m.LastUpdateTime)
+	}
+}
+
+func TestManabar_Regenerate(t *testing.T) {
+	params := ManabarParams{MaxMana: 432000000, RegenTime: 432000}
+	start := time.Unix(1600000000, 0)
+
+	tests := []struct {
+		name     string
+		current  int64
+		elapsed  time.Duration
+		expected int64
+	}{
+		{"no time passed", 1000, 0, 1000},
+		{"one second", 0, time.Second, 1000},
+		{"one day", 0, 24 * time.Hour, 86400000},
+		{"capped at max", 400000000, 5 * 24 * time.Hour, 432000000},
+		{"above max is clamped", 500000000, time.Second, 432000000},
+		{"negative mana regenerates", -1000, 2 * time.Second, 1000},
+		{"negative mana regenerates at most regen time", -1000, 10 * 24 * time.Hour, 431999000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Manabar{CurrentMana: Int64(tt.current), LastUpdateTime: UInt32(start.Unix())}
+			got := m.Regenerate(params, start.Add(tt.elapsed))
+			if int64(got.CurrentMana) != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, got.CurrentMana)
+			}
+		})
+	}
+}
+
+func TestManabar_Regenerate_Past(t *testing.T) {
+	m := Manabar{CurrentMana: 5, LastUpdateTime: 1600000000}
+	got := m.Regenerate(ManabarParams{MaxMana: 100, RegenTime: 10}, time.Unix(1500000000, 0))
+	if got != m {
+		t.Errorf("expected manabar to be unchanged, got %+v", got)
+	}
+}
+
+func TestManabar_UseMana(t *testing.T) {
+	m := Manabar{CurrentMana: 100}
+	if !m.HasMana(100) {
+		t.Error("expected HasMana(100) to be true")
+	}
+	if m.HasMana(101) {
+		t.Error("expected HasMana(101) to be false")
+	}
+	m.UseMana(150)
+	if m.CurrentMana != -50 {
+		t.Errorf("expected -50, got %d", m.CurrentMana)
+	}
+}
+
+func TestManabar_TimeToFull(t *testing.T) {
+	params := ManabarParams{MaxMana: 432000000, RegenTime: 432000}
+	m := Manabar{CurrentMana: 0, LastUpdateTime: 1600000000}
+	if got := m.TimeToFull(params); got != 432000*time.Second {
+		t.Errorf("expected 432000s, got %v", got)
+	}
+
+	m.CurrentMana = 431999999
+	if got := m.TimeToFull(params); got != time.Second {
+		t.Errorf("expected 1s, got %v", got)
+	}
+
+	m.CurrentMana = 432000000
+	if got := m.TimeToFull(params); got != 0 {
+		t.Errorf("expected 0, got %v", got)
+	}
+}
+
+func TestEffectiveVestingShares(t *testing.T) {
+	got, err := EffectiveVestingShares("1000.000000 VESTS", "200.000000 VESTS", "50.500000 VESTS", "0.000000 VESTS", 0, 0)
+	if err != nil {
+		t.Fatalf("EffectiveVestingShares failed: %v", err)
+	}
+	if got != 850500000 {
+		t.Errorf("expected 850500000, got %d", got)
+	}
+
+	// A power down subtracts the next withdrawal, capped at what is left.
+	got, err = EffectiveVestingShares("1000.000000 VESTS", "", "", "100.000000 VESTS", 1300000000, 1000000000)
+	if err != nil {
+		t.Fatalf("EffectiveVestingShares failed: %v", err)
+	}
+	if got != 900000000 {
+		t.Errorf("expected 900000000, got %d", got)
+	}
+	got, err = EffectiveVestingShares("1000.000000 VESTS", "", "", "100.000000 VESTS", 1300000000, 1250000000)
+	if err != nil {
+		t.Fatalf("EffectiveVestingShares failed: %v", err)
+	}
+	if got != 950000000 {
+		t.Errorf("expected 950000000, got %d", got)
+	}
+
+	if _, err := EffectiveVestingShares("1.000 STEEM", "", "", "", 0, 0); err == nil {
+		t.Error("expected error for non-VESTS asset")
+	}
+}
+
+func TestVotingMana(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	full := Manabar{CurrentMana: 1000000000000, LastUpdateTime: UInt32(now.Unix())}
+	downvote := Manabar{CurrentMana: 250000000000, LastUpdateTime: UInt32(now.Unix())}
+
+	vm, err := NewVotingMana(full, downvote, "1000000.000000 VESTS", "0.000000 VESTS", "0.000000 VESTS", "0.000000 VESTS", 0, 0)
+	if err != nil {
+		t.Fatalf("NewVotingMana failed: %v", err)
+	}
+
+	if got := vm.VotingPower(now); got != 10000 {
+		t.Errorf("expected voting power 10000, got %d", got)
+	}
+	if got := vm.DownvotePower(now); got != 10000 {
+		t.Errorf("expected downvote power 10000, got %d", got)
+	}
+	if got := vm.DownvoteParams().MaxMana; got != 250000000000 {
+		t.Errorf("expected downvote max mana 250000000000, got %d", got)
+	}
+
+	// A full-weight vote uses 2% of the mana:
+	// ceil(1e12 * 10000 * 86400 / 10000 / (10 * 432000)) = 20000000000.
+	if got := vm.UsedMana(10000, now); got != 20000000000 {
+		t.Errorf("expected used mana 20000000000, got %d", got)
+	}
+	if got := vm.RShares(10000, now); got != 20000000000-50000000 {
+		t.Errorf("expected rshares %d, got %d", int64(20000000000-50000000), got)
+	}
+	if got := vm.RShares(-5000, now); got != -(10000000000 - 50000000) {
+		t.Errorf("expected rshares %d, got %d", -(10000000000 - 50000000), got)
+	}
+
+	// Dust votes produce no rshares.
+	if got := vm.RShares(1, now); got != 0 {
+		t.Errorf("expected 0 rshares for dust vote, got %d", got)
+	}
+}
+
+func TestVotingMana_Downvote(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	voting := Manabar{CurrentMana: 100000000000, LastUpdateTime: UInt32(now.Unix())}
+	downvote := Manabar{CurrentMana: 250000000000, LastUpdateTime: UInt32(now.Unix())}
+	vm, err := NewVotingMana(voting, downvote, "1000000.000000 VESTS", "", "", "", 0, 0)
+	if err != nil {
+		t.Fatalf("NewVotingMana failed: %v", err)
+	}
+
+	// Upvotes use the 10% voting mana: ceil(1e11 * 86400 / 4320000) = 2e9.
+	if got := vm.UsedMana(10000, now); got != 2000000000 {
+		t.Errorf("expected used mana 2000000000, got %d", got)
+	}
+	// A full downvote manabar counts as 2.5e11 * 10000 / 2500 = 1e12 mana.
+	if got := vm.UsedMana(-10000, now); got != 20000000000 {
+		t.Errorf("expected used mana 20000000000, got %d", got)
+	}
+	if got := vm.RShares(-10000, now); got != -(20000000000 - 50000000) {
+		t.Errorf("expected rshares %d, got %d", -(20000000000 - 50000000), got)
+	}
+
+	// A nearly empty downvote manabar falls back to the voting mana.
+	vm.DownvoteManabar.CurrentMana = 1000000000
+	if got := vm.UsedMana(-10000, now); got != 2000000000 {
+		t.Errorf("expected used mana 2000000000, got %d", got)
+	}
+
+	// Without a downvote pool downvotes use the voting mana.
+	vm.DownvoteManabar.CurrentMana = 250000000000
+	vm.DownvotePoolPercent = 0
+	if got := vm.UsedMana(-10000, now); got != 2000000000 {
+		t.Errorf("expected used mana 2000000000, got %d", got)
+	}
+}
+
+func TestVotingMana_Regeneration(t *testing.T) {
+	last := time.Unix(1600000000, 0)
+	half := Manabar{CurrentMana: 500000000000, LastUpdateTime: UInt32(last.Unix())}
+	vm, err := NewVotingMana(half, half, "1000000.000000 VESTS", "", "", "", 0, 0)
+	if err != nil {
+		t.Fatalf("NewVotingMana failed: %v", err)
+	}
+
+	if got := vm.TimeToFull(last); got != 216000*time.Second {
+		t.Errorf("expected 216000s to full, got %v", got)
+	}
+
+	now := last.Add(24 * time.Hour)
+	if got := vm.VotingPower(now); got != 7000 {
+		t.Errorf("expected voting power 7000 after one day, got %d", got)
+	}
+	if got := vm.TimeToFull(now); got != 129600*time.Second {
+		t.Errorf("expected 129600s to full, got %v", got)
+	}
+
+	// The downvote manabar is already above its 25% cap.
+	if got := vm.DownvoteTimeToFull(now); got != 0 {
+		t.Errorf("expected downvote manabar to be full, got %v", got)
+	}
+}
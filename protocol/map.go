@@ -8,7 +8,7 @@ import (
 type StringInt64Map map[string]int64
 
 func (m StringInt64Map) MarshalJSON() ([]byte, error) {
-	xs := make([]interface{}, len(m))
+	xs := make([]interface{}, 0, len(m))
 	for k, v := range m {
 		xs = append(xs, []interface{}{k, v})
 	}
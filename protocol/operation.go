@@ -64,6 +64,17 @@ var dataObjects = map[OpType]Operation{
 	TypeFillVestingWithdraw:         &FillVestingWithdrawOperation{},
 	TypeFillOrder:                   &FillOrderOperation{},
 	TypeFillTransferFromSavings:     &FillTransferFromSavingsOperation{},
+	TypeAuthorReward:                &AuthorRewardOperation{},
+	TypeCurationReward:              &CurationRewardOperation{},
+	TypeShutdownWitness:             &ShutdownWitnessOperation{},
+	TypeHardfork:                    &HardforkOperation{},
+	TypeCommentPayoutUpdate:         &CommentPayoutUpdateOperation{},
+	TypeReturnVestingDelegation:     &ReturnVestingDelegationOperation{},
+	TypeCommentBenefactorReward:     &CommentBenefactorRewardOperation{},
+	TypeProducerReward:              &ProducerRewardOperation{},
+	TypeClearNullAccountBalance:     &ClearNullAccountBalanceOperation{},
+	TypeProposalPay:                 &ProposalPayOperation{},
+	TypeSPSFund:                     &SPSFundOperation{},
 }
 
 // Operation represents an operation stored in a transaction.
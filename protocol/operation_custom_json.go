@@ -1,25 +1,18 @@
 package protocol
 
 import (
-	"bytes"
-	"encoding/json"
-	"io"
-	"reflect"
 	"sort"
-	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/steemit/steemutil/encoder"
 )
 
 const (
-	TypeFollow = "follow"
+	TypeFollow    = "follow"
+	TypeCommunity = "community"
+	TypeNotify    = "notify"
 )
 
-var customJSONDataObjects = map[string]interface{}{
-	TypeFollow: &FollowOperation{},
-}
-
 // FC_REFLECT( steemit::chain::custom_json_operation,
 //             (required_auths)
 //             (required_posting_auths)
@@ -42,40 +35,16 @@ func (op *CustomJSONOperation) Data() interface{} {
 	return op
 }
 
+// UnmarshalData decodes JSON into the payload type registered for ID in
+// DefaultCustomJSONRegistry. It returns nil when no type is registered.
 func (op *CustomJSONOperation) UnmarshalData() (interface{}, error) {
-	// Get the corresponding data object template.
-	template, ok := customJSONDataObjects[op.ID]
-	if !ok {
-		// In case there is no corresponding template, return nil.
-		return nil, nil
-	}
-
-	// Clone the template.
-	opData := reflect.New(reflect.Indirect(reflect.ValueOf(template)).Type()).Interface()
-
-	// Prepare the whole operation tuple.
-	var bodyReader io.Reader
-	if op.JSON[0] == '[' {
-		rawTuple := make([]json.RawMessage, 2)
-		if err := json.NewDecoder(strings.NewReader(op.JSON)).Decode(&rawTuple); err != nil {
-			return nil, errors.Wrapf(err,
-				"failed to unmarshal CustomJSONOperation.JSON: \n%v", op.JSON)
-		}
-		if rawTuple[1] == nil {
-			return nil, errors.Errorf("invalid CustomJSONOperation.JSON: \n%v", op.JSON)
-		}
-		bodyReader = bytes.NewReader([]byte(rawTuple[1]))
-	} else {
-		bodyReader = strings.NewReader(op.JSON)
-	}
-
-	// Unmarshal into the new object instance.
-	if err := json.NewDecoder(bodyReader).Decode(opData); err != nil {
-		return nil, errors.Wrapf(err,
-			"failed to unmarshal CustomJSONOperation.JSON: \n%v", op.JSON)
-	}
+	return DefaultCustomJSONRegistry.Decode(op)
+}
 
-	return opData, nil
+// newCustomJSONAction builds a custom_json operation with an [action, payload]
+// body, authorized by the posting key of account.
+func newCustomJSONAction(id, account, action string, payload interface{}) (*CustomJSONOperation, error) {
+	return EncodeCustomJSON(id, action, payload, nil, []string{account})
 }
 
 // MarshalTransaction implements custom binary serialization for custom_json operation.
@@ -87,8 +56,8 @@ func (op *CustomJSONOperation) MarshalTransaction(encoderObj *encoder.Encoder) e
 	}
 
 	// Encode operation type code first (required for all operations)
-	if err := encoderObj.EncodeUVarint(uint64(op.Type().Code())); err != nil {
-		return errors.Wrap(err, "failed to encode operation type code")
+	if err := encodeOpCode(encoderObj, op.Type()); err != nil {
+		return err
 	}
 
 	// Sort required_auths (flat_set serialization requires sorted order)
@@ -0,0 +1,184 @@
+package protocol
+
+// Actions of the community custom_json id.
+const (
+	ActionSubscribe    = "subscribe"
+	ActionUnsubscribe  = "unsubscribe"
+	ActionSetRole      = "setRole"
+	ActionSetUserTitle = "setUserTitle"
+	ActionMutePost     = "mutePost"
+	ActionUnmutePost   = "unmutePost"
+	ActionFlagPost     = "flagPost"
+	ActionPinPost      = "pinPost"
+	ActionUnpinPost    = "unpinPost"
+	ActionUpdateProps  = "updateProps"
+)
+
+// Community roles used by setRole.
+const (
+	RoleMuted  = "muted"
+	RoleGuest  = "guest"
+	RoleMember = "member"
+	RoleMod    = "mod"
+	RoleAdmin  = "admin"
+)
+
+type SubscribeOperation struct {
+	Community string `json:"community"`
+}
+
+type UnsubscribeOperation struct {
+	Community string `json:"community"`
+}
+
+type SetRoleOperation struct {
+	Community string `json:"community"`
+	Account   string `json:"account"`
+	Role      string `json:"role"`
+}
+
+type SetUserTitleOperation struct {
+	Community string `json:"community"`
+	Account   string `json:"account"`
+	Title     string `json:"title"`
+}
+
+type MutePostOperation struct {
+	Community string `json:"community"`
+	Account   string `json:"account"`
+	Permlink  string `json:"permlink"`
+	Notes     string `json:"notes"`
+}
+
+type UnmutePostOperation struct {
+	Community string `json:"community"`
+	Account   string `json:"account"`
+	Permlink  string `json:"permlink"`
+	Notes     string `json:"notes"`
+}
+
+type FlagPostOperation struct {
+	Community string `json:"community"`
+	Account   string `json:"account"`
+	Permlink  string `json:"permlink"`
+	Notes     string `json:"notes"`
+}
+
+type PinPostOperation struct {
+	Community string `json:"community"`
+	Account   string `json:"account"`
+	Permlink  string `json:"permlink"`
+}
+
+type UnpinPostOperation struct {
+	Community string `json:"community"`
+	Account   string `json:"account"`
+	Permlink  string `json:"permlink"`
+}
+
+// CommunityProps are the community settings changed by updateProps. Only
+// the set fields are sent.
+type CommunityProps struct {
+	Title       *string                `json:"title,omitempty"`
+	About       *string                `json:"about,omitempty"`
+	Lang        *string                `json:"lang,omitempty"`
+	IsNSFW      *bool                  `json:"is_nsfw,omitempty"`
+	Description *string                `json:"description,omitempty"`
+	FlagText    *string                `json:"flag_text,omitempty"`
+	Settings    map[string]interface{} `json:"settings,omitempty"`
+}
+
+type UpdatePropsOperation struct {
+	Community string          `json:"community"`
+	Props     *CommunityProps `json:"props"`
+}
+
+// NewSubscribeOperation builds a community subscribe custom_json.
+func NewSubscribeOperation(account, community string) (*CustomJSONOperation, error) {
+	return newCustomJSONAction(TypeCommunity, account, ActionSubscribe, &SubscribeOperation{Community: community})
+}
+
+// NewUnsubscribeOperation builds a community unsubscribe custom_json.
+func NewUnsubscribeOperation(account, community string) (*CustomJSONOperation, error) {
+	return newCustomJSONAction(TypeCommunity, account, ActionUnsubscribe, &UnsubscribeOperation{Community: community})
+}
+
+// NewSetRoleOperation builds a custom_json in which moderator sets the role
+// of account in the community.
+func NewSetRoleOperation(moderator, community, account, role string) (*CustomJSONOperation, error) {
+	return newCustomJSONAction(TypeCommunity, moderator, ActionSetRole, &SetRoleOperation{
+		Community: community,
+		Account:   account,
+		Role:      role,
+	})
+}
+
+// NewSetUserTitleOperation builds a custom_json in which moderator sets the
+// title of account in the community.
+func NewSetUserTitleOperation(moderator, community, account, title string) (*CustomJSONOperation, error) {
+	return newCustomJSONAction(TypeCommunity, moderator, ActionSetUserTitle, &SetUserTitleOperation{
+		Community: community,
+		Account:   account,
+		Title:     title,
+	})
+}
+
+// NewMutePostOperation builds a custom_json in which moderator mutes the
+// post account/permlink in the community.
+func NewMutePostOperation(moderator, community, account, permlink, notes string) (*CustomJSONOperation, error) {
+	return newCustomJSONAction(TypeCommunity, moderator, ActionMutePost, &MutePostOperation{
+		Community: community,
+		Account:   account,
+		Permlink:  permlink,
+		Notes:     notes,
+	})
+}
+
+// NewUnmutePostOperation builds a custom_json undoing NewMutePostOperation.
+func NewUnmutePostOperation(moderator, community, account, permlink, notes string) (*CustomJSONOperation, error) {
+	return newCustomJSONAction(TypeCommunity, moderator, ActionUnmutePost, &UnmutePostOperation{
+		Community: community,
+		Account:   account,
+		Permlink:  permlink,
+		Notes:     notes,
+	})
+}
+
+// NewFlagPostOperation builds a custom_json in which reporter flags the post
+// account/permlink to the community moderators.
+func NewFlagPostOperation(reporter, community, account, permlink, notes string) (*CustomJSONOperation, error) {
+	return newCustomJSONAction(TypeCommunity, reporter, ActionFlagPost, &FlagPostOperation{
+		Community: community,
+		Account:   account,
+		Permlink:  permlink,
+		Notes:     notes,
+	})
+}
+
+// NewPinPostOperation builds a custom_json in which moderator pins the post
+// account/permlink in the community.
+func NewPinPostOperation(moderator, community, account, permlink string) (*CustomJSONOperation, error) {
+	return newCustomJSONAction(TypeCommunity, moderator, ActionPinPost, &PinPostOperation{
+		Community: community,
+		Account:   account,
+		Permlink:  permlink,
+	})
+}
+
+// NewUnpinPostOperation builds a custom_json undoing NewPinPostOperation.
+func NewUnpinPostOperation(moderator, community, account, permlink string) (*CustomJSONOperation, error) {
+	return newCustomJSONAction(TypeCommunity, moderator, ActionUnpinPost, &UnpinPostOperation{
+		Community: community,
+		Account:   account,
+		Permlink:  permlink,
+	})
+}
+
+// NewUpdatePropsOperation builds a custom_json in which admin updates the
+// community settings.
+func NewUpdatePropsOperation(admin, community string, props *CommunityProps) (*CustomJSONOperation, error) {
+	return newCustomJSONAction(TypeCommunity, admin, ActionUpdateProps, &UpdatePropsOperation{
+		Community: community,
+		Props:     props,
+	})
+}
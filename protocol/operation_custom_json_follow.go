@@ -1,7 +1,65 @@
 package protocol
 
+// Actions of the follow custom_json id.
+const (
+	ActionFollow = "follow"
+	ActionReblog = "reblog"
+)
+
+// Values of FollowOperation.What.
+const (
+	FollowBlog   = "blog"
+	FollowIgnore = "ignore"
+)
+
 type FollowOperation struct {
 	Follower  string   `json:"follower"`
 	Following string   `json:"following"`
 	What      []string `json:"what"`
 }
+
+// ReblogOperation is the body of a reblog action. Delete is "delete" when the
+// reblog is undone.
+type ReblogOperation struct {
+	Account  string `json:"account"`
+	Author   string `json:"author"`
+	Permlink string `json:"permlink"`
+	Delete   string `json:"delete,omitempty"`
+}
+
+// NewFollowOperation builds a follow custom_json. What is FollowBlog to
+// follow, FollowIgnore to mute and empty to unfollow or unmute.
+func NewFollowOperation(follower, following string, what ...string) (*CustomJSONOperation, error) {
+	if what == nil {
+		what = []string{}
+	}
+	return newCustomJSONAction(TypeFollow, follower, ActionFollow, &FollowOperation{
+		Follower:  follower,
+		Following: following,
+		What:      what,
+	})
+}
+
+// NewMuteOperation builds a follow custom_json muting an account.
+func NewMuteOperation(follower, following string) (*CustomJSONOperation, error) {
+	return NewFollowOperation(follower, following, FollowIgnore)
+}
+
+// NewReblogOperation builds a reblog custom_json.
+func NewReblogOperation(account, author, permlink string) (*CustomJSONOperation, error) {
+	return newCustomJSONAction(TypeFollow, account, ActionReblog, &ReblogOperation{
+		Account:  account,
+		Author:   author,
+		Permlink: permlink,
+	})
+}
+
+// NewDeleteReblogOperation builds a custom_json undoing a reblog.
+func NewDeleteReblogOperation(account, author, permlink string) (*CustomJSONOperation, error) {
+	return newCustomJSONAction(TypeFollow, account, ActionReblog, &ReblogOperation{
+		Account:  account,
+		Author:   author,
+		Permlink: permlink,
+		Delete:   "delete",
+	})
+}
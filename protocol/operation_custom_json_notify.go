@@ -0,0 +1,21 @@
+package protocol
+
+import "time"
+
+// Actions of the notify custom_json id.
+const (
+	ActionSetLastRead = "setLastRead"
+)
+
+// SetLastReadOperation marks the notifications up to Date as read.
+type SetLastReadOperation struct {
+	Date string `json:"date"`
+}
+
+// NewSetLastReadOperation builds a notify custom_json marking the
+// notifications of account up to date as read.
+func NewSetLastReadOperation(account string, date time.Time) (*CustomJSONOperation, error) {
+	return newCustomJSONAction(TypeNotify, account, ActionSetLastRead, &SetLastReadOperation{
+		Date: date.UTC().Format(LayoutWithoutQuotes),
+	})
+}
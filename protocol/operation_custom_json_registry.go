@@ -0,0 +1,308 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// CustomJSONDecodeFunc decodes the raw JSON of a custom_json payload, whatever
+// its shape. A nil payload without error leaves the payload undecoded.
+// SplitCustomJSON and CustomJSONVersion help with [action, body] and
+// versioned payloads.
+type CustomJSONDecodeFunc func(data json.RawMessage) (interface{}, error)
+
+// CustomJSONValidateFunc checks a decoded or to be encoded payload.
+type CustomJSONValidateFunc func(action string, payload interface{}) error
+
+// CustomJSONRegistry maps custom_json ids to payload types.
+//
+// Payloads are decoded, in order of preference, by the decoder registered for
+// the id, which receives the raw JSON, by the type registered for the id and
+// action of an [action, body] payload, or by the type registered for the id
+// alone. Validators of the id run after decoding and
+// before encoding, as does the Validate method of payloads implementing it.
+type CustomJSONRegistry struct {
+	mu  sync.RWMutex
+	ids map[string]*customJSONEntry
+}
+
+type customJSONEntry struct {
+	types      map[string]reflect.Type
+	decode     CustomJSONDecodeFunc
+	validators []CustomJSONValidateFunc
+}
+
+// NewCustomJSONRegistry creates an empty registry.
+func NewCustomJSONRegistry() *CustomJSONRegistry {
+	return &CustomJSONRegistry{ids: make(map[string]*customJSONEntry)}
+}
+
+// DefaultCustomJSONRegistry is used by CustomJSONOperation.UnmarshalData and
+// EncodeCustomJSON. It has the follow, community and notify payloads
+// registered.
+var DefaultCustomJSONRegistry = NewCustomJSONRegistry()
+
+func init() {
+	builtins := []struct {
+		id, action string
+		template   interface{}
+	}{
+		{TypeFollow, "", &FollowOperation{}},
+		{TypeFollow, ActionFollow, &FollowOperation{}},
+		{TypeFollow, ActionReblog, &ReblogOperation{}},
+		{TypeCommunity, ActionSubscribe, &SubscribeOperation{}},
+		{TypeCommunity, ActionUnsubscribe, &UnsubscribeOperation{}},
+		{TypeCommunity, ActionSetRole, &SetRoleOperation{}},
+		{TypeCommunity, ActionSetUserTitle, &SetUserTitleOperation{}},
+		{TypeCommunity, ActionMutePost, &MutePostOperation{}},
+		{TypeCommunity, ActionUnmutePost, &UnmutePostOperation{}},
+		{TypeCommunity, ActionFlagPost, &FlagPostOperation{}},
+		{TypeCommunity, ActionPinPost, &PinPostOperation{}},
+		{TypeCommunity, ActionUnpinPost, &UnpinPostOperation{}},
+		{TypeCommunity, ActionUpdateProps, &UpdatePropsOperation{}},
+		{TypeNotify, ActionSetLastRead, &SetLastReadOperation{}},
+	}
+	for _, b := range builtins {
+		if err := DefaultCustomJSONRegistry.RegisterType(b.id, b.action, b.template); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (r *CustomJSONRegistry) entry(id string) *customJSONEntry {
+	e, ok := r.ids[id]
+	if !ok {
+		e = &customJSONEntry{types: make(map[string]reflect.Type)}
+		r.ids[id] = e
+	}
+	return e
+}
+
+// RegisterType registers the type of template, a pointer to a struct, as the
+// payload of id and action. Use an empty action for plain object payloads.
+func (r *CustomJSONRegistry) RegisterType(id, action string, template interface{}) error {
+	t := reflect.TypeOf(template)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return errors.Errorf("custom_json template for %s/%s must be a pointer", id, action)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e := r.entry(id)
+	if _, ok := e.types[action]; ok {
+		return errors.Errorf("custom_json payload %s/%s already registered", id, action)
+	}
+	e.types[action] = t.Elem()
+	return nil
+}
+
+// RegisterDecoder registers the decoder of id, taking precedence over the
+// registered types.
+func (r *CustomJSONRegistry) RegisterDecoder(id string, decode CustomJSONDecodeFunc) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e := r.entry(id)
+	if e.decode != nil {
+		return errors.Errorf("custom_json decoder for %s already registered", id)
+	}
+	e.decode = decode
+	return nil
+}
+
+// RegisterValidator adds a validator of the payloads of id.
+func (r *CustomJSONRegistry) RegisterValidator(id string, validate CustomJSONValidateFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e := r.entry(id)
+	e.validators = append(e.validators, validate)
+}
+
+// Decode decodes the payload of op. It returns nil without error when
+// nothing is registered for the id and action.
+func (r *CustomJSONRegistry) Decode(op *CustomJSONOperation) (interface{}, error) {
+	r.mu.RLock()
+	e, ok := r.ids[op.ID]
+	var decode CustomJSONDecodeFunc
+	var types map[string]reflect.Type
+	var validators []CustomJSONValidateFunc
+	if ok {
+		decode = e.decode
+		types = e.types
+		validators = e.validators
+	}
+	r.mu.RUnlock()
+	if decode == nil && len(types) == 0 {
+		// Payloads of unregistered ids are not parsed at all.
+		return nil, nil
+	}
+
+	if decode != nil {
+		payload, err := decode(json.RawMessage(op.JSON))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode custom_json %s", op.ID)
+		}
+		if payload == nil {
+			return nil, nil
+		}
+		action, _, _ := SplitCustomJSON(op.JSON)
+		if err := validateCustomJSON(op.ID, action, payload, validators); err != nil {
+			return nil, err
+		}
+		return payload, nil
+	}
+
+	action, body, err := SplitCustomJSON(op.JSON)
+	if err != nil {
+		return nil, err
+	}
+	t, ok := types[action]
+	if !ok {
+		t = types[""]
+	}
+
+	var payload interface{}
+	switch {
+	case t != nil:
+		payload = reflect.New(t).Interface()
+		if err := json.NewDecoder(bytes.NewReader(body)).Decode(payload); err != nil {
+			return nil, errors.Wrapf(err,
+				"failed to unmarshal CustomJSONOperation.JSON: \n%v", op.JSON)
+		}
+	default:
+		// In case there is no corresponding template, return nil.
+		return nil, nil
+	}
+
+	if err := validateCustomJSON(op.ID, action, payload, validators); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// Encode validates payload and builds a custom_json operation for id. The
+// JSON is [action, payload], or payload alone when action is empty.
+func (r *CustomJSONRegistry) Encode(id, action string, payload interface{}, requiredAuths, requiredPostingAuths []string) (*CustomJSONOperation, error) {
+	r.mu.RLock()
+	var validators []CustomJSONValidateFunc
+	if e, ok := r.ids[id]; ok {
+		validators = e.validators
+	}
+	r.mu.RUnlock()
+
+	if err := validateCustomJSON(id, action, payload, validators); err != nil {
+		return nil, err
+	}
+	if len(requiredAuths) == 0 && len(requiredPostingAuths) == 0 {
+		return nil, errors.Errorf("custom_json %s requires an authority", id)
+	}
+
+	var body interface{} = payload
+	if action != "" {
+		body = []interface{}{action, payload}
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal custom_json %s", id)
+	}
+
+	if requiredAuths == nil {
+		requiredAuths = []string{}
+	}
+	if requiredPostingAuths == nil {
+		requiredPostingAuths = []string{}
+	}
+	return &CustomJSONOperation{
+		RequiredAuths:        requiredAuths,
+		RequiredPostingAuths: requiredPostingAuths,
+		ID:                   id,
+		JSON:                 string(data),
+	}, nil
+}
+
+// RegisterCustomJSONType calls RegisterType on DefaultCustomJSONRegistry.
+func RegisterCustomJSONType(id, action string, template interface{}) error {
+	return DefaultCustomJSONRegistry.RegisterType(id, action, template)
+}
+
+// RegisterCustomJSONDecoder calls RegisterDecoder on DefaultCustomJSONRegistry.
+func RegisterCustomJSONDecoder(id string, decode CustomJSONDecodeFunc) error {
+	return DefaultCustomJSONRegistry.RegisterDecoder(id, decode)
+}
+
+// RegisterCustomJSONValidator calls RegisterValidator on
+// DefaultCustomJSONRegistry.
+func RegisterCustomJSONValidator(id string, validate CustomJSONValidateFunc) {
+	DefaultCustomJSONRegistry.RegisterValidator(id, validate)
+}
+
+// EncodeCustomJSON calls Encode on DefaultCustomJSONRegistry.
+func EncodeCustomJSON(id, action string, payload interface{}, requiredAuths, requiredPostingAuths []string) (*CustomJSONOperation, error) {
+	return DefaultCustomJSONRegistry.Encode(id, action, payload, requiredAuths, requiredPostingAuths)
+}
+
+// SplitCustomJSON splits an [action, body] payload. Other payloads are
+// returned as the body with an empty action.
+func SplitCustomJSON(data string) (string, json.RawMessage, error) {
+	trimmed := strings.TrimSpace(data)
+	if trimmed == "" {
+		return "", nil, errors.New("empty CustomJSONOperation.JSON")
+	}
+	if trimmed[0] != '[' {
+		return "", json.RawMessage(trimmed), nil
+	}
+
+	var rawTuple []json.RawMessage
+	if err := json.Unmarshal([]byte(trimmed), &rawTuple); err != nil {
+		return "", nil, errors.Wrapf(err,
+			"failed to unmarshal CustomJSONOperation.JSON: \n%v", data)
+	}
+	if len(rawTuple) != 2 || rawTuple[1] == nil {
+		return "", nil, errors.Errorf("invalid CustomJSONOperation.JSON: \n%v", data)
+	}
+	var action string
+	if err := json.Unmarshal(rawTuple[0], &action); err != nil {
+		return "", nil, errors.Wrapf(err,
+			"invalid CustomJSONOperation.JSON action: \n%v", data)
+	}
+	return action, rawTuple[1], nil
+}
+
+// CustomJSONVersion reads the numeric "version" or "v" field of an object,
+// returning 0 when it is missing.
+func CustomJSONVersion(body json.RawMessage) int {
+	var fields struct {
+		Version *json.Number `json:"version"`
+		V       *json.Number `json:"v"`
+	}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return 0
+	}
+	for _, n := range []*json.Number{fields.Version, fields.V} {
+		if n == nil {
+			continue
+		}
+		if v, err := n.Int64(); err == nil {
+			return int(v)
+		}
+	}
+	return 0
+}
+
+func validateCustomJSON(id, action string, payload interface{}, validators []CustomJSONValidateFunc) error {
+	if v, ok := payload.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid custom_json %s payload", id)
+		}
+	}
+	for _, validate := range validators {
+		if err := validate(action, payload); err != nil {
+			return errors.Wrapf(err, "invalid custom_json %s payload", id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package protocol
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+type testGamePayload struct {
+	Player string `json:"player"`
+	Score  int    `json:"score"`
+}
+
+func (p *testGamePayload) Validate() error {
+	if p.Player == "" {
+		return errors.New("player is required")
+	}
+	return nil
+}
+
+type testGamePayloadV2 struct {
+	Player string `json:"player"`
+	Points int    `json:"points"`
+}
+
+func TestCustomJSONRegistryType(t *testing.T) {
+	r := NewCustomJSONRegistry()
+	if err := r.RegisterType("game", "score", &testGamePayload{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RegisterType("game", "score", &testGamePayload{}); err == nil {
+		t.Error("expected an error registering a payload twice")
+	}
+	if err := r.RegisterType("game", "other", testGamePayload{}); err == nil {
+		t.Error("expected an error registering a non pointer template")
+	}
+
+	var checked []string
+	r.RegisterValidator("game", func(action string, payload interface{}) error {
+		checked = append(checked, action)
+		if payload.(*testGamePayload).Score < 0 {
+			return errors.New("negative score")
+		}
+		return nil
+	})
+
+	op, err := r.Encode("game", "score", &testGamePayload{Player: "alice", Score: 7}, nil, []string{"alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.JSON != `["score",{"player":"alice","score":7}]` {
+		t.Errorf("expected %v, got %v", `["score",{"player":"alice","score":7}]`, op.JSON)
+	}
+	if op.RequiredAuths == nil || len(op.RequiredAuths) != 0 {
+		t.Errorf("expected empty required auths, got %v", op.RequiredAuths)
+	}
+
+	decoded, err := r.Decode(op)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, ok := decoded.(*testGamePayload); !ok || p.Player != "alice" || p.Score != 7 {
+		t.Errorf("expected decoded payload, got %#v", decoded)
+	}
+	if strings.Join(checked, ",") != "score,score" {
+		t.Errorf("expected validator to run on encode and decode, got %v", checked)
+	}
+
+	if _, err := r.Encode("game", "score", &testGamePayload{Player: "alice", Score: -1}, nil, []string{"alice"}); err == nil {
+		t.Error("expected the validator to reject the payload")
+	}
+	if _, err := r.Encode("game", "score", &testGamePayload{Score: 1}, nil, []string{"alice"}); err == nil {
+		t.Error("expected the payload Validate method to reject the payload")
+	}
+	if _, err := r.Encode("game", "score", &testGamePayload{Player: "alice"}, nil, nil); err == nil {
+		t.Error("expected an error without authorities")
+	}
+	bad := &CustomJSONOperation{ID: "game", JSON: `["score",{"player":"","score":1}]`}
+	if _, err := r.Decode(bad); err == nil {
+		t.Error("expected decoding to validate the payload")
+	}
+
+	unknown := &CustomJSONOperation{ID: "game", JSON: `["unknown",{}]`}
+	if decoded, err := r.Decode(unknown); err != nil || decoded != nil {
+		t.Errorf("expected nil for an unregistered action, got %v, %v", decoded, err)
+	}
+}
+
+func TestCustomJSONRegistryDecoder(t *testing.T) {
+	r := NewCustomJSONRegistry()
+	err := r.RegisterDecoder("game", func(data json.RawMessage) (interface{}, error) {
+		_, body, err := SplitCustomJSON(string(data))
+		if err != nil {
+			return nil, err
+		}
+		switch CustomJSONVersion(body) {
+		case 0, 1:
+			var p testGamePayload
+			return &p, json.Unmarshal(body, &p)
+		case 2:
+			var p testGamePayloadV2
+			return &p, json.Unmarshal(body, &p)
+		}
+		return nil, errors.Errorf("unsupported version %d", CustomJSONVersion(body))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RegisterDecoder("game", nil); err == nil {
+		t.Error("expected an error registering a decoder twice")
+	}
+
+	tests := []struct {
+		json     string
+		expected interface{}
+	}{
+		{`{"player":"alice","score":3}`, &testGamePayload{Player: "alice", Score: 3}},
+		{`{"version":1,"player":"alice","score":3}`, &testGamePayload{Player: "alice", Score: 3}},
+		{`{"v":2,"player":"bob","points":5}`, &testGamePayloadV2{Player: "bob", Points: 5}},
+		{`["play",{"version":2,"player":"bob","points":5}]`, &testGamePayloadV2{Player: "bob", Points: 5}},
+	}
+	for _, tt := range tests {
+		decoded, err := r.Decode(&CustomJSONOperation{ID: "game", JSON: tt.json})
+		if err != nil {
+			t.Errorf("%s: %v", tt.json, err)
+			continue
+		}
+		got, _ := json.Marshal(decoded)
+		want, _ := json.Marshal(tt.expected)
+		if string(got) != string(want) {
+			t.Errorf("expected %s, got %s", want, got)
+		}
+	}
+
+	if _, err := r.Decode(&CustomJSONOperation{ID: "game", JSON: `{"version":3}`}); err == nil {
+		t.Error("expected an error for an unsupported version")
+	}
+}
+
+func TestCustomJSONRegistryDecoderRawJSON(t *testing.T) {
+	type contractCall struct {
+		ContractName   string `json:"contractName"`
+		ContractAction string `json:"contractAction"`
+	}
+	r := NewCustomJSONRegistry()
+	var got []string
+	err := r.RegisterDecoder("ssc-mainnet1", func(data json.RawMessage) (interface{}, error) {
+		got = append(got, string(data))
+		var calls []contractCall
+		if err := json.Unmarshal(data, &calls); err != nil {
+			return nil, nil
+		}
+		return calls, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Payloads that are not [action, body] tuples reach the decoder as is.
+	payloads := []string{
+		`[{"contractName":"tokens","contractAction":"transfer"},{"contractName":"market","contractAction":"buy"}]`,
+		`["a",1,2]`,
+		`[1,{"a":1}]`,
+	}
+	for _, payload := range payloads {
+		if _, err := r.Decode(&CustomJSONOperation{ID: "ssc-mainnet1", JSON: payload}); err != nil {
+			t.Errorf("%s: %v", payload, err)
+		}
+	}
+	if strings.Join(got, "\n") != strings.Join(payloads, "\n") {
+		t.Errorf("expected %v, got %v", payloads, got)
+	}
+
+	decoded, err := r.Decode(&CustomJSONOperation{ID: "ssc-mainnet1", JSON: payloads[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls, ok := decoded.([]contractCall)
+	if !ok || len(calls) != 2 || calls[1].ContractAction != "buy" {
+		t.Errorf("unexpected payload %v", decoded)
+	}
+}
+
+func TestCustomJSONRegistryUnregistered(t *testing.T) {
+	// Payloads of unregistered ids decode to nil whatever their shape.
+	payloads := []string{
+		`[{"contractName":"tokens","contractAction":"transfer","contractPayload":{"symbol":"BEE","to":"bob","quantity":"1"}},{"contractName":"market","contractAction":"buy","contractPayload":{}}]`,
+		`["a",1,2]`,
+		`[1,{"a":1}]`,
+		`"plain string"`,
+		``,
+	}
+	for _, payload := range payloads {
+		decoded, err := DefaultCustomJSONRegistry.Decode(&CustomJSONOperation{ID: "ssc-mainnet1", JSON: payload})
+		if err != nil {
+			t.Errorf("%q: %v", payload, err)
+		}
+		if decoded != nil {
+			t.Errorf("%q: expected nil, got %v", payload, decoded)
+		}
+	}
+}
+
+func TestEncodeCustomJSONPlainObject(t *testing.T) {
+	op, err := EncodeCustomJSON(TypeFollow, "", &FollowOperation{Follower: "alice", Following: "bob", What: []string{FollowBlog}}, []string{"alice"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(op.JSON, "{") || op.RequiredPostingAuths == nil {
+		t.Errorf("expected a plain object payload, got %v", op)
+	}
+	decoded, err := op.UnmarshalData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f, ok := decoded.(*FollowOperation); !ok || f.Following != "bob" {
+		t.Errorf("expected follow payload, got %#v", decoded)
+	}
+}
@@ -3,8 +3,10 @@ package protocol
 import (
 	"bytes"
 	"encoding/hex"
+	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/steemit/steemutil/encoder"
 )
@@ -438,3 +440,182 @@ func TestCustomJSONOperation_FullOperationEncoding(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomJSONBuilders(t *testing.T) {
+	str := func(s string) *string { return &s }
+	date := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		build   func() (*CustomJSONOperation, error)
+		id      string
+		json    string
+		account string
+		payload interface{}
+	}{
+		{
+			name:    "follow",
+			build:   func() (*CustomJSONOperation, error) { return NewFollowOperation("alice", "bob", FollowBlog) },
+			id:      "follow",
+			json:    `["follow",{"follower":"alice","following":"bob","what":["blog"]}]`,
+			account: "alice",
+			payload: &FollowOperation{Follower: "alice", Following: "bob", What: []string{"blog"}},
+		},
+		{
+			name:    "unfollow",
+			build:   func() (*CustomJSONOperation, error) { return NewFollowOperation("alice", "bob") },
+			id:      "follow",
+			json:    `["follow",{"follower":"alice","following":"bob","what":[]}]`,
+			account: "alice",
+			payload: &FollowOperation{Follower: "alice", Following: "bob", What: []string{}},
+		},
+		{
+			name:    "mute",
+			build:   func() (*CustomJSONOperation, error) { return NewMuteOperation("alice", "bob") },
+			id:      "follow",
+			json:    `["follow",{"follower":"alice","following":"bob","what":["ignore"]}]`,
+			account: "alice",
+			payload: &FollowOperation{Follower: "alice", Following: "bob", What: []string{"ignore"}},
+		},
+		{
+			name:    "reblog",
+			build:   func() (*CustomJSONOperation, error) { return NewReblogOperation("alice", "bob", "post") },
+			id:      "follow",
+			json:    `["reblog",{"account":"alice","author":"bob","permlink":"post"}]`,
+			account: "alice",
+			payload: &ReblogOperation{Account: "alice", Author: "bob", Permlink: "post"},
+		},
+		{
+			name:    "delete reblog",
+			build:   func() (*CustomJSONOperation, error) { return NewDeleteReblogOperation("alice", "bob", "post") },
+			id:      "follow",
+			json:    `["reblog",{"account":"alice","author":"bob","permlink":"post","delete":"delete"}]`,
+			account: "alice",
+			payload: &ReblogOperation{Account: "alice", Author: "bob", Permlink: "post", Delete: "delete"},
+		},
+		{
+			name:    "subscribe",
+			build:   func() (*CustomJSONOperation, error) { return NewSubscribeOperation("alice", "hive-123456") },
+			id:      "community",
+			json:    `["subscribe",{"community":"hive-123456"}]`,
+			account: "alice",
+			payload: &SubscribeOperation{Community: "hive-123456"},
+		},
+		{
+			name:    "unsubscribe",
+			build:   func() (*CustomJSONOperation, error) { return NewUnsubscribeOperation("alice", "hive-123456") },
+			id:      "community",
+			json:    `["unsubscribe",{"community":"hive-123456"}]`,
+			account: "alice",
+			payload: &UnsubscribeOperation{Community: "hive-123456"},
+		},
+		{
+			name: "setRole",
+			build: func() (*CustomJSONOperation, error) {
+				return NewSetRoleOperation("mod", "hive-123456", "bob", RoleMember)
+			},
+			id:      "community",
+			json:    `["setRole",{"community":"hive-123456","account":"bob","role":"member"}]`,
+			account: "mod",
+			payload: &SetRoleOperation{Community: "hive-123456", Account: "bob", Role: "member"},
+		},
+		{
+			name: "setUserTitle",
+			build: func() (*CustomJSONOperation, error) {
+				return NewSetUserTitleOperation("mod", "hive-123456", "bob", "Helper")
+			},
+			id:      "community",
+			json:    `["setUserTitle",{"community":"hive-123456","account":"bob","title":"Helper"}]`,
+			account: "mod",
+			payload: &SetUserTitleOperation{Community: "hive-123456", Account: "bob", Title: "Helper"},
+		},
+		{
+			name: "mutePost",
+			build: func() (*CustomJSONOperation, error) {
+				return NewMutePostOperation("mod", "hive-123456", "bob", "post", "spam")
+			},
+			id:      "community",
+			json:    `["mutePost",{"community":"hive-123456","account":"bob","permlink":"post","notes":"spam"}]`,
+			account: "mod",
+			payload: &MutePostOperation{Community: "hive-123456", Account: "bob", Permlink: "post", Notes: "spam"},
+		},
+		{
+			name: "flagPost",
+			build: func() (*CustomJSONOperation, error) {
+				return NewFlagPostOperation("carol", "hive-123456", "bob", "post", "off topic")
+			},
+			id:      "community",
+			json:    `["flagPost",{"community":"hive-123456","account":"bob","permlink":"post","notes":"off topic"}]`,
+			account: "carol",
+			payload: &FlagPostOperation{Community: "hive-123456", Account: "bob", Permlink: "post", Notes: "off topic"},
+		},
+		{
+			name:    "pinPost",
+			build:   func() (*CustomJSONOperation, error) { return NewPinPostOperation("mod", "hive-123456", "bob", "post") },
+			id:      "community",
+			json:    `["pinPost",{"community":"hive-123456","account":"bob","permlink":"post"}]`,
+			account: "mod",
+			payload: &PinPostOperation{Community: "hive-123456", Account: "bob", Permlink: "post"},
+		},
+		{
+			name: "updateProps",
+			build: func() (*CustomJSONOperation, error) {
+				return NewUpdatePropsOperation("admin", "hive-123456", &CommunityProps{Title: str("Test"), About: str("About")})
+			},
+			id:      "community",
+			json:    `["updateProps",{"community":"hive-123456","props":{"title":"Test","about":"About"}}]`,
+			account: "admin",
+			payload: &UpdatePropsOperation{Community: "hive-123456", Props: &CommunityProps{Title: str("Test"), About: str("About")}},
+		},
+		{
+			name:    "setLastRead",
+			build:   func() (*CustomJSONOperation, error) { return NewSetLastReadOperation("alice", date) },
+			id:      "notify",
+			json:    `["setLastRead",{"date":"2023-01-01T00:00:00"}]`,
+			account: "alice",
+			payload: &SetLastReadOperation{Date: "2023-01-01T00:00:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := tt.build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if op.ID != tt.id || op.JSON != tt.json {
+				t.Errorf("expected %s %s, got %s %s", tt.id, tt.json, op.ID, op.JSON)
+			}
+			if len(op.RequiredAuths) != 0 || !reflect.DeepEqual(op.RequiredPostingAuths, []string{tt.account}) {
+				t.Errorf("unexpected auths: %v %v", op.RequiredAuths, op.RequiredPostingAuths)
+			}
+
+			payload, err := op.UnmarshalData()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(payload, tt.payload) {
+				t.Errorf("expected %+v, got %+v", tt.payload, payload)
+			}
+		})
+	}
+}
+
+func TestCustomJSONUnmarshalDataLegacyFollow(t *testing.T) {
+	op := &CustomJSONOperation{ID: "follow", JSON: `{"follower":"alice","following":"bob","what":["blog"]}`}
+	payload, err := op.UnmarshalData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &FollowOperation{Follower: "alice", Following: "bob", What: []string{"blog"}}
+	if !reflect.DeepEqual(payload, expected) {
+		t.Errorf("expected %+v, got %+v", expected, payload)
+	}
+
+	for _, input := range []string{"", "[", `["follow"]`} {
+		op := &CustomJSONOperation{ID: "follow", JSON: input}
+		if _, err := op.UnmarshalData(); err == nil {
+			t.Errorf("expected an error for %q", input)
+		}
+	}
+}
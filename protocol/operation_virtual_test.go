@@ -0,0 +1,106 @@
+package protocol
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// appliedOperation is an entry of a get_ops_in_block result.
+type appliedOperation struct {
+	Block     uint32          `json:"block"`
+	VirtualOp uint32          `json:"virtual_op"`
+	Op        *operationTuple `json:"op"`
+}
+
+// decodeOps decodes [type, body] operation tuples.
+func decodeOps(t *testing.T, tuples ...string) []Operation {
+	t.Helper()
+	ops := make([]Operation, 0, len(tuples))
+	for _, tuple := range tuples {
+		var op operationTuple
+		if err := json.Unmarshal([]byte(tuple), &op); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := op.Data.(*UnknownOperation); ok {
+			t.Errorf("unexpected unknown operation: %s", op.Type)
+		}
+		ops = append(ops, op.Data)
+	}
+	return ops
+}
+
+func TestVirtualOperations(t *testing.T) {
+	got := decodeOps(t,
+		`["author_reward",{"author":"alice","permlink":"hello-world","sbd_payout":"0.000 SBD","steem_payout":"1.234 STEEM","vesting_payout":"2345.678901 VESTS"}]`,
+		`["curation_reward",{"curator":"bob","reward":"123.456789 VESTS","comment_author":"alice","comment_permlink":"hello-world"}]`,
+		`["comment_benefactor_reward",{"benefactor":"carol","author":"alice","permlink":"hello-world","sbd_payout":"0.000 SBD","steem_payout":"0.100 STEEM","vesting_payout":"190.000000 VESTS"}]`,
+		`["comment_payout_update",{"author":"alice","permlink":"hello-world"}]`,
+		`["return_vesting_delegation",{"account":"dave","vesting_shares":"1000.000000 VESTS"}]`,
+		`["fill_vesting_withdraw",{"from_account":"erin","to_account":"erin","withdrawn":"5000.000000 VESTS","deposited":"2.500 STEEM"}]`,
+		`["fill_vesting_withdraw",{"from_account":"erin","to_account":"frank","withdrawn":"5000.000000 VESTS","deposited":"5000.000000 VESTS"}]`,
+		`["proposal_pay",{"receiver":"steem.dao","payment":"10.000 SBD","trx_id":"0000000000000000000000000000000000000000","op_in_trx":0}]`,
+		`["sps_fund",{"additional_funds":"4.321 SBD"}]`,
+		`["producer_reward",{"producer":"witness1","vesting_shares":"480.123456 VESTS"}]`,
+	)
+	expected := []Operation{
+		&AuthorRewardOperation{Author: "alice", Permlink: "hello-world", SBDPayout: "0.000 SBD", SteemPayout: "1.234 STEEM", VestingPayout: "2345.678901 VESTS"},
+		&CurationRewardOperation{Curator: "bob", Reward: "123.456789 VESTS", CommentAuthor: "alice", CommentPermlink: "hello-world"},
+		&CommentBenefactorRewardOperation{Benefactor: "carol", Author: "alice", Permlink: "hello-world", SBDPayout: "0.000 SBD", SteemPayout: "0.100 STEEM", VestingPayout: "190.000000 VESTS"},
+		&CommentPayoutUpdateOperation{Author: "alice", Permlink: "hello-world"},
+		&ReturnVestingDelegationOperation{Account: "dave", VestingShares: "1000.000000 VESTS"},
+		&FillVestingWithdrawOperation{FromAccount: "erin", ToAccount: "erin", Withdrawn: "5000.000000 VESTS", Deposited: "2.500 STEEM"},
+		&FillVestingWithdrawOperation{FromAccount: "erin", ToAccount: "frank", Withdrawn: "5000.000000 VESTS", Deposited: "5000.000000 VESTS"},
+		&ProposalPayOperation{Receiver: "steem.dao", Payment: "10.000 SBD", TrxID: "0000000000000000000000000000000000000000"},
+		&SPSFundOperation{AdditionalFunds: "4.321 SBD"},
+		&ProducerRewardOperation{Producer: "witness1", VestingShares: "480.123456 VESTS"},
+	}
+	checkOps(t, got, expected)
+}
+
+func TestVirtualOperationsHardfork(t *testing.T) {
+	got := decodeOps(t,
+		`["hardfork",{"hardfork_id":22}]`,
+		`["shutdown_witness",{"owner":"oldwitness"}]`,
+		`["clear_null_account_balance",{"total_cleared":["1.000 STEEM","0.500 SBD"]}]`,
+		// Older blocks carry the benefactor reward as a single VESTS amount.
+		`["comment_benefactor_reward",{"benefactor":"carol","author":"alice","permlink":"old-post","reward":"190.000000 VESTS"}]`,
+	)
+	expected := []Operation{
+		&HardforkOperation{HardforkID: 22},
+		&ShutdownWitnessOperation{Owner: "oldwitness"},
+		&ClearNullAccountBalanceOperation{TotalCleared: []string{"1.000 STEEM", "0.500 SBD"}},
+		&CommentBenefactorRewardOperation{Benefactor: "carol", Author: "alice", Permlink: "old-post", Reward: "190.000000 VESTS"},
+	}
+	checkOps(t, got, expected)
+}
+
+func TestAppliedOperation(t *testing.T) {
+	data := `{"trx_id":"0000000000000000000000000000000000000000","block":50000000,"trx_in_block":4294967295,"op_in_trx":0,"virtual_op":1,"timestamp":"2021-01-01T00:00:00","op":["producer_reward",{"producer":"witness1","vesting_shares":"480.123456 VESTS"}]}`
+	var applied appliedOperation
+	if err := json.Unmarshal([]byte(data), &applied); err != nil {
+		t.Fatal(err)
+	}
+	if applied.Block != 50000000 || applied.VirtualOp != 1 {
+		t.Errorf("unexpected entry %+v", applied)
+	}
+	expected := &ProducerRewardOperation{Producer: "witness1", VestingShares: "480.123456 VESTS"}
+	if !reflect.DeepEqual(applied.Op.Data, expected) {
+		t.Errorf("expected %+v, got %+v", expected, applied.Op.Data)
+	}
+}
+
+func checkOps(t *testing.T, got, expected []Operation) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d operations, got %d", len(expected), len(got))
+	}
+	for i := range expected {
+		if got[i].Type() != expected[i].Type() {
+			t.Errorf("expected %v, got %v", expected[i].Type(), got[i].Type())
+		}
+		if !reflect.DeepEqual(got[i], expected[i]) {
+			t.Errorf("expected %+v, got %+v", expected[i], got[i])
+		}
+	}
+}
@@ -1,9 +1,13 @@
 package protocol
 
 import (
+	"bytes"
 	"encoding/json"
+	"sort"
 
+	"github.com/pkg/errors"
 	"github.com/steemit/steemutil/encoder"
+	"github.com/steemit/steemutil/wif"
 )
 
 // FC_REFLECT( steemit::chain::report_over_production_operation,
@@ -254,6 +258,30 @@ func (op *AccountCreateOperation) Data() any {
 	return op
 }
 
+func (op *AccountCreateOperation) MarshalTransaction(encoderObj *encoder.Encoder) error {
+	if err := encodeOpCode(encoderObj, op.Type()); err != nil {
+		return err
+	}
+	if err := encodeAsset(encoderObj, op.Fee); err != nil {
+		return errors.Wrap(err, "failed to encode fee")
+	}
+
+	enc := encoder.NewRollingEncoder(encoderObj)
+	enc.Encode(op.Creator)
+	enc.Encode(op.NewAccountName)
+	enc.Encode(op.Owner)
+	enc.Encode(op.Active)
+	enc.Encode(op.Posting)
+	if err := enc.Err(); err != nil {
+		return err
+	}
+
+	if err := encodePublicKey(encoderObj, op.MemoKey); err != nil {
+		return errors.Wrap(err, "failed to encode memo_key")
+	}
+	return encoderObj.Encode(op.JsonMetadata)
+}
+
 // FC_REFLECT( steemit::chain::account_update_operation,
 //             (account)
 //             (owner)
@@ -420,8 +448,10 @@ func (op *CommentOperation) IsStoryOperation() bool {
 }
 
 func (op *CommentOperation) MarshalTransaction(encoderObj *encoder.Encoder) error {
+	if err := encodeOpCode(encoderObj, op.Type()); err != nil {
+		return err
+	}
 	enc := encoder.NewRollingEncoder(encoderObj)
-	enc.EncodeUVarint(uint64(op.Type().Code()))
 	enc.Encode(op.ParentAuthor)
 	enc.Encode(op.ParentPermlink)
 	enc.Encode(op.Author)
@@ -454,8 +484,10 @@ func (op *VoteOperation) Data() any {
 }
 
 func (op *VoteOperation) MarshalTransaction(encoderObj *encoder.Encoder) error {
+	if err := encodeOpCode(encoderObj, TypeVote); err != nil {
+		return err
+	}
 	enc := encoder.NewRollingEncoder(encoderObj)
-	enc.EncodeUVarint(uint64(TypeVote.Code()))
 	enc.Encode(op.Voter)
 	enc.Encode(op.Author)
 	enc.Encode(op.Permlink)
@@ -554,12 +586,229 @@ func (op *CommentOptionsOperation) Data() any {
 	return op
 }
 
+// CommentPayoutBeneficiaries is the comment_options extension with variant
+// index 0, routing a share of the author reward to other accounts.
+const CommentPayoutBeneficiaries = 0
+
+// FC_REFLECT( steemit::protocol::beneficiary_route_type,
+//             (account)
+//             (weight) )
+
+// Beneficiary receives Weight basis points of the author reward.
+type Beneficiary struct {
+	Account string `json:"account"`
+	Weight  uint16 `json:"weight"`
+}
+
+type commentPayoutBeneficiaries struct {
+	Beneficiaries []Beneficiary `json:"beneficiaries"`
+}
+
+// NewBeneficiariesExtension builds the extension value setting the given
+// beneficiaries, sorted by account as steemd requires.
+func NewBeneficiariesExtension(beneficiaries []Beneficiary) any {
+	sorted := make([]Beneficiary, len(beneficiaries))
+	copy(sorted, beneficiaries)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Account < sorted[j].Account
+	})
+	return []any{CommentPayoutBeneficiaries, &commentPayoutBeneficiaries{Beneficiaries: sorted}}
+}
+
+// Beneficiaries returns the beneficiaries set by the extensions, which are
+// either built by NewBeneficiariesExtension or decoded from JSON.
+func (op *CommentOptionsOperation) Beneficiaries() ([]Beneficiary, error) {
+	var beneficiaries []Beneficiary
+	for _, ext := range op.Extensions {
+		raw, err := json.Marshal(ext)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read comment_options extension")
+		}
+		var tuple []json.RawMessage
+		if err := json.Unmarshal(raw, &tuple); err != nil || len(tuple) != 2 {
+			return nil, errors.Errorf("invalid comment_options extension: %s", raw)
+		}
+		var kind int
+		if err := json.Unmarshal(tuple[0], &kind); err != nil || kind != CommentPayoutBeneficiaries {
+			return nil, errors.Errorf("unsupported comment_options extension: %s", raw)
+		}
+		var value commentPayoutBeneficiaries
+		if err := json.Unmarshal(tuple[1], &value); err != nil {
+			return nil, errors.Wrapf(err, "invalid comment_options extension: %s", raw)
+		}
+		beneficiaries = append(beneficiaries, value.Beneficiaries...)
+	}
+	return beneficiaries, nil
+}
+
+func (op *CommentOptionsOperation) MarshalTransaction(encoderObj *encoder.Encoder) error {
+	beneficiaries, err := op.Beneficiaries()
+	if err != nil {
+		return err
+	}
+	if err := encodeOpCode(encoderObj, op.Type()); err != nil {
+		return err
+	}
+	enc := encoder.NewRollingEncoder(encoderObj)
+	enc.Encode(op.Author)
+	enc.Encode(op.Permlink)
+	if err := enc.Err(); err != nil {
+		return err
+	}
+	if err := encodeAsset(encoderObj, op.MaxAcceptedPayout); err != nil {
+		return errors.Wrap(err, "failed to encode max_accepted_payout")
+	}
+
+	enc.Encode(op.PercentSteemDollars)
+	enc.Encode(op.AllowVotes)
+	enc.Encode(op.AllowCurationRewards)
+	// Only the beneficiaries extension is supported. It is written as its
+	// variant index followed by the route vector.
+	if len(beneficiaries) == 0 {
+		enc.EncodeUVarint(0)
+		return enc.Err()
+	}
+	enc.EncodeUVarint(1)
+	enc.EncodeUVarint(CommentPayoutBeneficiaries)
+	enc.EncodeUVarint(uint64(len(beneficiaries)))
+	for _, b := range beneficiaries {
+		enc.Encode(b.Account)
+		enc.Encode(b.Weight)
+	}
+	return enc.Err()
+}
+
+// FC_REFLECT( steemit::chain::authority,
+//             (weight_threshold)
+//             (account_auths)
+//             (key_auths) )
+
 type Authority struct {
 	AccountAuths    StringInt64Map `json:"account_auths"`
 	KeyAuths        StringInt64Map `json:"key_auths"`
 	WeightThreshold uint32         `json:"weight_threshold"`
 }
 
+// Equal reports whether both authorities have the same threshold and
+// weights. Keys are compared by value, whatever their address prefix.
+func (auth *Authority) Equal(other *Authority) bool {
+	if auth == nil || other == nil {
+		return auth == other
+	}
+	if auth.WeightThreshold != other.WeightThreshold ||
+		len(auth.AccountAuths) != len(other.AccountAuths) || len(auth.KeyAuths) != len(other.KeyAuths) {
+		return false
+	}
+	for name, weight := range auth.AccountAuths {
+		if w, ok := other.AccountAuths[name]; !ok || w != weight {
+			return false
+		}
+	}
+	keys := make(map[string]int64, len(other.KeyAuths))
+	for key, weight := range other.KeyAuths {
+		keys[authorityKey(key)] = weight
+	}
+	for key, weight := range auth.KeyAuths {
+		if w, ok := keys[authorityKey(key)]; !ok || w != weight {
+			return false
+		}
+	}
+	return true
+}
+
+// IsImpossible reports whether the weights of all accounts and keys together
+// cannot reach the threshold, like authority::is_impossible in steemd.
+func (auth *Authority) IsImpossible() bool {
+	var total uint64
+	for _, weight := range auth.AccountAuths {
+		total += uint64(uint16(weight))
+	}
+	for _, weight := range auth.KeyAuths {
+		total += uint64(uint16(weight))
+	}
+	return total < uint64(auth.WeightThreshold)
+}
+
+// authorityKey returns the raw form of a public key, or the string itself
+// when it does not parse.
+func authorityKey(key string) string {
+	pubKey := &wif.PublicKey{}
+	if err := pubKey.FromStrAnyPrefix(key); err != nil {
+		return key
+	}
+	return string(pubKey.ToByte())
+}
+
+// MarshalTransaction encodes the authority in the FC_REFLECT field order.
+// Both maps are flat_maps, so their entries are written sorted by key.
+func (auth *Authority) MarshalTransaction(encoderObj *encoder.Encoder) error {
+	if auth == nil {
+		return errors.New("authority is nil")
+	}
+
+	enc := encoder.NewRollingEncoder(encoderObj)
+	enc.Encode(auth.WeightThreshold)
+
+	accounts := make([]string, 0, len(auth.AccountAuths))
+	for account := range auth.AccountAuths {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+
+	enc.EncodeUVarint(uint64(len(accounts)))
+	for _, account := range accounts {
+		enc.Encode(account)
+		enc.Encode(uint16(auth.AccountAuths[account]))
+	}
+
+	keys := make([][]byte, 0, len(auth.KeyAuths))
+	weights := make(map[string]uint16, len(auth.KeyAuths))
+	for keyStr, weight := range auth.KeyAuths {
+		pubKey := &wif.PublicKey{}
+		if err := pubKey.FromStrAnyPrefix(keyStr); err != nil {
+			return errors.Wrapf(err, "invalid key in authority: %s", keyStr)
+		}
+		raw := pubKey.ToByte()
+		keys = append(keys, raw)
+		weights[string(raw)] = uint16(weight)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	enc.EncodeUVarint(uint64(len(keys)))
+	for _, raw := range keys {
+		enc.Encode(encodedBytes(raw))
+		enc.Encode(weights[string(raw)])
+	}
+	return enc.Err()
+}
+
+// encodedBytes is written to the encoder as is, without a length prefix.
+type encodedBytes []byte
+
+func (b encodedBytes) MarshalTransaction(encoderObj *encoder.Encoder) error {
+	return encoderObj.WriteBytes(b)
+}
+
+// encodePublicKey writes a public key string as its 33-byte compressed form.
+func encodePublicKey(encoderObj *encoder.Encoder, pubKeyStr string) error {
+	pubKey := &wif.PublicKey{}
+	if err := pubKey.FromStrAnyPrefix(pubKeyStr); err != nil {
+		return errors.Wrapf(err, "invalid public key: %s", pubKeyStr)
+	}
+	return encoderObj.WriteBytes(pubKey.ToByte())
+}
+
+// encodeAsset writes an asset string such as "3.000 STEEM" in binary form.
+func encodeAsset(encoderObj *encoder.Encoder, assetStr string) error {
+	asset, err := ParseAsset(assetStr)
+	if err != nil {
+		return err
+	}
+	return asset.MarshalTransaction(encoderObj)
+}
+
 // FC_REFLECT( steemit::chain::witness_update_operation,
 //             (owner)
 //             (url)
@@ -680,6 +929,29 @@ func (op *CreateClaimedAccountOperation) Data() any {
 	return op
 }
 
+func (op *CreateClaimedAccountOperation) MarshalTransaction(encoderObj *encoder.Encoder) error {
+	if err := encodeOpCode(encoderObj, op.Type()); err != nil {
+		return err
+	}
+	enc := encoder.NewRollingEncoder(encoderObj)
+	enc.Encode(op.Creator)
+	enc.Encode(op.NewAccountName)
+	enc.Encode(op.Owner)
+	enc.Encode(op.Active)
+	enc.Encode(op.Posting)
+	if err := enc.Err(); err != nil {
+		return err
+	}
+
+	if err := encodePublicKey(encoderObj, op.MemoKey); err != nil {
+		return errors.Wrap(err, "failed to encode memo_key")
+	}
+	if err := encoderObj.Encode(op.JsonMetadata); err != nil {
+		return err
+	}
+	return encoderObj.EncodeUVarint(uint64(len(op.Extensions)))
+}
+
 // FC_REFLECT( steemit::chain::request_account_recovery_operation,
 //             (recovery_account)
 //             (account_to_recover)
@@ -930,12 +1202,20 @@ func (op *CancelTransferFromSavingsOperation) Data() any {
 }
 
 // FC_REFLECT( steemit::chain::custom_binary_operation,
+//             (required_owner_auths)
+//             (required_active_auths)
+//             (required_posting_auths)
+//             (required_auths)
 //             (id)
 //             (data) )
 
 type CustomBinaryOperation struct {
-	ID        string `json:"id"`
-	DataBytes string `json:"data"`
+	RequiredOwnerAuths   []string     `json:"required_owner_auths"`
+	RequiredActiveAuths  []string     `json:"required_active_auths"`
+	RequiredPostingAuths []string     `json:"required_posting_auths"`
+	RequiredAuths        []*Authority `json:"required_auths"`
+	ID                   string       `json:"id"`
+	DataBytes            string       `json:"data"`
 }
 
 func (op *CustomBinaryOperation) Type() OpType {
@@ -1376,6 +1656,211 @@ func (op *FillTransferFromSavingsOperation) Data() any {
 	return op
 }
 
+// FC_REFLECT( steemit::chain::author_reward_operation,
+//             (author)
+//             (permlink)
+//             (sbd_payout)
+//             (steem_payout)
+//             (vesting_payout) )
+
+type AuthorRewardOperation struct {
+	Author        string `json:"author"`
+	Permlink      string `json:"permlink"`
+	SBDPayout     string `json:"sbd_payout"`
+	SteemPayout   string `json:"steem_payout"`
+	VestingPayout string `json:"vesting_payout"`
+}
+
+func (op *AuthorRewardOperation) Type() OpType {
+	return TypeAuthorReward
+}
+
+func (op *AuthorRewardOperation) Data() any {
+	return op
+}
+
+// FC_REFLECT( steemit::chain::curation_reward_operation,
+//             (curator)
+//             (reward)
+//             (comment_author)
+//             (comment_permlink) )
+
+type CurationRewardOperation struct {
+	Curator         string `json:"curator"`
+	Reward          string `json:"reward"`
+	CommentAuthor   string `json:"comment_author"`
+	CommentPermlink string `json:"comment_permlink"`
+}
+
+func (op *CurationRewardOperation) Type() OpType {
+	return TypeCurationReward
+}
+
+func (op *CurationRewardOperation) Data() any {
+	return op
+}
+
+// FC_REFLECT( steemit::chain::shutdown_witness_operation,
+//             (owner) )
+
+type ShutdownWitnessOperation struct {
+	Owner string `json:"owner"`
+}
+
+func (op *ShutdownWitnessOperation) Type() OpType {
+	return TypeShutdownWitness
+}
+
+func (op *ShutdownWitnessOperation) Data() any {
+	return op
+}
+
+// FC_REFLECT( steemit::chain::hardfork_operation,
+//             (hardfork_id) )
+
+type HardforkOperation struct {
+	HardforkID uint32 `json:"hardfork_id"`
+}
+
+func (op *HardforkOperation) Type() OpType {
+	return TypeHardfork
+}
+
+func (op *HardforkOperation) Data() any {
+	return op
+}
+
+// FC_REFLECT( steemit::chain::comment_payout_update_operation,
+//             (author)
+//             (permlink) )
+
+type CommentPayoutUpdateOperation struct {
+	Author   string `json:"author"`
+	Permlink string `json:"permlink"`
+}
+
+func (op *CommentPayoutUpdateOperation) Type() OpType {
+	return TypeCommentPayoutUpdate
+}
+
+func (op *CommentPayoutUpdateOperation) Data() any {
+	return op
+}
+
+// FC_REFLECT( steemit::chain::return_vesting_delegation_operation,
+//             (account)
+//             (vesting_shares) )
+
+type ReturnVestingDelegationOperation struct {
+	Account       string `json:"account"`
+	VestingShares string `json:"vesting_shares"`
+}
+
+func (op *ReturnVestingDelegationOperation) Type() OpType {
+	return TypeReturnVestingDelegation
+}
+
+func (op *ReturnVestingDelegationOperation) Data() any {
+	return op
+}
+
+// FC_REFLECT( steemit::chain::comment_benefactor_reward_operation,
+//             (benefactor)
+//             (author)
+//             (permlink)
+//             (sbd_payout)
+//             (steem_payout)
+//             (vesting_payout) )
+
+// CommentBenefactorRewardOperation reports a beneficiary payout. Older blocks
+// carry the payout as a single VESTS amount in Reward instead of the three
+// payout fields.
+type CommentBenefactorRewardOperation struct {
+	Benefactor    string `json:"benefactor"`
+	Author        string `json:"author"`
+	Permlink      string `json:"permlink"`
+	SBDPayout     string `json:"sbd_payout"`
+	SteemPayout   string `json:"steem_payout"`
+	VestingPayout string `json:"vesting_payout"`
+	Reward        string `json:"reward,omitempty"`
+}
+
+func (op *CommentBenefactorRewardOperation) Type() OpType {
+	return TypeCommentBenefactorReward
+}
+
+func (op *CommentBenefactorRewardOperation) Data() any {
+	return op
+}
+
+// FC_REFLECT( steemit::chain::producer_reward_operation,
+//             (producer)
+//             (vesting_shares) )
+
+type ProducerRewardOperation struct {
+	Producer      string `json:"producer"`
+	VestingShares string `json:"vesting_shares"`
+}
+
+func (op *ProducerRewardOperation) Type() OpType {
+	return TypeProducerReward
+}
+
+func (op *ProducerRewardOperation) Data() any {
+	return op
+}
+
+// FC_REFLECT( steemit::chain::clear_null_account_balance_operation,
+//             (total_cleared) )
+
+type ClearNullAccountBalanceOperation struct {
+	TotalCleared []string `json:"total_cleared"`
+}
+
+func (op *ClearNullAccountBalanceOperation) Type() OpType {
+	return TypeClearNullAccountBalance
+}
+
+func (op *ClearNullAccountBalanceOperation) Data() any {
+	return op
+}
+
+// FC_REFLECT( steemit::chain::proposal_pay_operation,
+//             (receiver)
+//             (payment)
+//             (trx_id)
+//             (op_in_trx) )
+
+type ProposalPayOperation struct {
+	Receiver string `json:"receiver"`
+	Payment  string `json:"payment"`
+	TrxID    string `json:"trx_id"`
+	OpInTrx  uint16 `json:"op_in_trx"`
+}
+
+func (op *ProposalPayOperation) Type() OpType {
+	return TypeProposalPay
+}
+
+func (op *ProposalPayOperation) Data() any {
+	return op
+}
+
+// FC_REFLECT( steemit::chain::sps_fund_operation,
+//             (additional_funds) )
+
+type SPSFundOperation struct {
+	AdditionalFunds string `json:"additional_funds"`
+}
+
+func (op *SPSFundOperation) Type() OpType {
+	return TypeSPSFund
+}
+
+func (op *SPSFundOperation) Data() any {
+	return op
+}
+
 type UnknownOperation struct {
 	kind OpType
 	data *json.RawMessage
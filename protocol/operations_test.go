@@ -705,3 +705,112 @@ func TestPOWOperation_Type(t *testing.T) {
 		t.Errorf("expected TypePOW, got %v", op.Type())
 	}
 }
+
+func TestAuthority_MarshalTransaction(t *testing.T) {
+	auth := &Authority{
+		AccountAuths: StringInt64Map{"bob": 2, "alice": 1},
+		KeyAuths: StringInt64Map{
+			"STM8UnUGrV8vMAMtHQjNiNchjnViZSnme4puQk8HYKVojVjXppZQP": 1,
+			"STM5drwFJSU3dcvCLXKc9zuTMRT5iJg42C7nFhbBmySfnwBxA8bQd": 3,
+		},
+		WeightThreshold: 2,
+	}
+
+	// weight_threshold, then account_auths and key_auths sorted by key.
+	expectedHex := "02000000" +
+		"02" + "05616c6963650100" + "03626f620200" +
+		"02" + "02627b4fb238a22388bba29d8b04d28ba8eb74d9e074563ee9250de509b40674ab" + "0300" +
+		"03d8f5a64d4a29509dc00edfc42de768a70155ca4c2a1c0c97cf8126cbd94ca171" + "0100"
+
+	var b bytes.Buffer
+	if err := encoder.NewEncoder(&b).Encode(auth); err != nil {
+		t.Fatal(err)
+	}
+
+	serializedHex := hex.EncodeToString(b.Bytes())
+	if serializedHex != expectedHex {
+		t.Errorf("expected %v, got %v", expectedHex, serializedHex)
+	}
+}
+
+func TestAuthority_MarshalTransactionInvalidKey(t *testing.T) {
+	auth := &Authority{KeyAuths: StringInt64Map{"STM5drwFJSU3dcvCLXKc9zuTMRT5iJg42C7nFhbBmySfnwBxA8bQe": 1}, WeightThreshold: 1}
+
+	var b bytes.Buffer
+	if err := encoder.NewEncoder(&b).Encode(auth); err == nil {
+		t.Error("expected error for invalid key")
+	}
+}
+
+func TestStringInt64Map_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(StringInt64Map{"alice": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `[["alice",1]]`
+	if string(data) != expected {
+		t.Errorf("expected %v, got %v", expected, string(data))
+	}
+}
+
+func TestAccountCreateOperation_MarshalTransaction(t *testing.T) {
+	key := "STM5drwFJSU3dcvCLXKc9zuTMRT5iJg42C7nFhbBmySfnwBxA8bQd"
+	newAuth := func() *Authority {
+		return &Authority{
+			AccountAuths:    StringInt64Map{},
+			KeyAuths:        StringInt64Map{key: 1},
+			WeightThreshold: 1,
+		}
+	}
+	op := &AccountCreateOperation{
+		Fee:            "3.000 STEEM",
+		Creator:        "alice",
+		NewAccountName: "bob",
+		Owner:          newAuth(),
+		Active:         newAuth(),
+		Posting:        newAuth(),
+		MemoKey:        key,
+		JsonMetadata:   "",
+	}
+
+	keyHex := "02627b4fb238a22388bba29d8b04d28ba8eb74d9e074563ee9250de509b40674ab"
+	authHex := "01000000" + "00" + "01" + keyHex + "0100"
+	expectedHex := "09" +
+		"b80b000000000000" + "03" + "535445454d0000" +
+		"05616c696365" + "03626f62" +
+		authHex + authHex + authHex +
+		keyHex + "00"
+
+	var b bytes.Buffer
+	if err := encoder.NewEncoder(&b).Encode(op); err != nil {
+		t.Fatal(err)
+	}
+
+	serializedHex := hex.EncodeToString(b.Bytes())
+	if serializedHex != expectedHex {
+		t.Errorf("expected %v, got %v", expectedHex, serializedHex)
+	}
+
+	claimed := &CreateClaimedAccountOperation{
+		Creator:        "alice",
+		NewAccountName: "bob",
+		Owner:          newAuth(),
+		Active:         newAuth(),
+		Posting:        newAuth(),
+		MemoKey:        key,
+		Extensions:     []any{},
+	}
+	b.Reset()
+	if err := encoder.NewEncoder(&b).Encode(claimed); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedHex = "17" + "05616c696365" + "03626f62" +
+		authHex + authHex + authHex +
+		keyHex + "00" + "00"
+	serializedHex = hex.EncodeToString(b.Bytes())
+	if serializedHex != expectedHex {
+		t.Errorf("expected %v, got %v", expectedHex, serializedHex)
+	}
+}
@@ -0,0 +1,674 @@
+package protocol
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"unicode/utf8"
+
+	"github.com/steemit/steemutil/consts"
+	"github.com/steemit/steemutil/wif"
+)
+
+// Validate methods enforce the rules of the steemd operation validate()
+// functions, so that invalid operations fail before being broadcast. They
+// return a *FieldError naming the first invalid field.
+//
+// They deliberately differ from steemd in a few places, checking rules that
+// steemd enforces in the evaluator: account names in custom_json auths and
+// the MaxCommentBeneficiaries limit of comment_options.
+
+func (op *ReportOverProductionOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("reporter", op.Reporter)
+	return v.Err()
+}
+
+func (op *ConvertOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("owner", op.Owner)
+	v.positiveAsset("amount", op.Amount, assetSBD)
+	return v.Err()
+}
+
+func (op *FeedPublishOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("publisher", op.Publisher)
+	validatePrice(v, "exchange_rate", op.ExchangeRate.Base, op.ExchangeRate.Quote)
+	return v.Err()
+}
+
+func (props *ChainProperties) validate(v *opValidator, field string) {
+	if props == nil {
+		v.fail(field, "is required")
+		return
+	}
+	v.nonNegativeAsset(field+".account_creation_fee", props.AccountCreationFee, assetSteem)
+	v.check(props.MaximumBlockSize >= MinBlockSizeLimit, field+".maximum_block_size",
+		"must be at least %d", MinBlockSizeLimit)
+	v.check(props.SBDInterestRate <= Percent100, field+".sbd_interest_rate",
+		"must not exceed %d", Percent100)
+}
+
+func (op *POWOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("worker_account", op.WorkerAccount)
+	op.Props.validate(v, "props")
+	return v.Err()
+}
+
+func (op *AccountCreateOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("creator", op.Creator)
+	v.account("new_account_name", op.NewAccountName)
+	v.nonNegativeAsset("fee", op.Fee, assetSteem)
+	v.authority("owner", op.Owner, false)
+	v.authority("active", op.Active, false)
+	v.authority("posting", op.Posting, false)
+	v.publicKey("memo_key", op.MemoKey)
+	v.jsonString("json_metadata", op.JsonMetadata)
+	return v.Err()
+}
+
+func (op *AccountUpdateOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("account", op.Account)
+	v.authority("owner", op.Owner, true)
+	v.authority("active", op.Active, true)
+	v.authority("posting", op.Posting, true)
+	if op.MemoKey != "" {
+		v.publicKey("memo_key", op.MemoKey)
+	}
+	v.jsonString("json_metadata", op.JsonMetadata)
+	return v.Err()
+}
+
+func (op *TransferOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("from", op.From)
+	v.account("to", op.To)
+	v.positiveAsset("amount", op.Amount, assetSteem|assetSBD)
+	v.memo("memo", op.Memo)
+	return v.Err()
+}
+
+func (op *TransferToVestingOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("from", op.From)
+	v.optionalAccount("to", op.To)
+	v.positiveAsset("amount", op.Amount, assetSteem)
+	return v.Err()
+}
+
+func (op *WithdrawVestingOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("account", op.Account)
+	v.nonNegativeAsset("vesting_shares", op.VestingShares, assetVests)
+	return v.Err()
+}
+
+func (op *AccountWitnessVoteOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("account", op.Account)
+	v.account("witness", op.Witness)
+	return v.Err()
+}
+
+func (op *AccountWitnessProxyOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("account", op.Account)
+	v.optionalAccount("proxy", op.Proxy)
+	v.check(op.Proxy != op.Account, "proxy", "cannot proxy to self")
+	return v.Err()
+}
+
+func (op *CommentOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.optionalAccount("parent_author", op.ParentAuthor)
+	v.account("author", op.Author)
+	v.permlink("parent_permlink", op.ParentPermlink)
+	v.permlink("permlink", op.Permlink)
+	v.check(op.Permlink != "", "permlink", "is required")
+	v.check(len(op.Title) < consts.SteemMainnet.CommentTitleLimit, "title",
+		"must be shorter than %d bytes", consts.SteemMainnet.CommentTitleLimit)
+	v.utf8("title", op.Title)
+	v.check(op.Body != "", "body", "is required")
+	v.utf8("body", op.Body)
+	v.jsonString("json_metadata", op.JsonMetadata)
+	return v.Err()
+}
+
+func (op *VoteOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("voter", op.Voter)
+	v.account("author", op.Author)
+	v.permlink("permlink", op.Permlink)
+	v.check(op.Weight >= -Percent100 && op.Weight <= Percent100, "weight",
+		"must be between -%d and %d", Percent100, Percent100)
+	return v.Err()
+}
+
+func (op *LimitOrderCreateOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("owner", op.Owner)
+	validatePrice(v, "", op.AmountToSell, op.MinToReceive)
+	return v.Err()
+}
+
+func (op *LimitOrderCancelOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("owner", op.Owner)
+	return v.Err()
+}
+
+func (op *DeleteCommentOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("author", op.Author)
+	v.permlink("permlink", op.Permlink)
+	return v.Err()
+}
+
+func (op *CommentOptionsOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("author", op.Author)
+	v.permlink("permlink", op.Permlink)
+	v.check(op.PercentSteemDollars <= Percent100, "percent_steem_dollars",
+		"must not exceed %d", Percent100)
+	v.nonNegativeAsset("max_accepted_payout", op.MaxAcceptedPayout, assetSBD)
+
+	beneficiaries, err := op.Beneficiaries()
+	if err != nil {
+		v.fail("extensions", "%v", err)
+		return v.Err()
+	}
+	v.check(len(op.Extensions) == 0 || len(beneficiaries) > 0, "extensions.beneficiaries",
+		"must specify at least one beneficiary")
+	v.check(len(beneficiaries) <= MaxBeneficiaryRoutes, "extensions.beneficiaries",
+		"must not exceed %d entries", MaxBeneficiaryRoutes)
+	// steemd only enforces this limit when applying the operation.
+	v.check(len(beneficiaries) <= MaxCommentBeneficiaries, "extensions.beneficiaries",
+		"must not exceed %d entries", MaxCommentBeneficiaries)
+	var total int
+	for i, b := range beneficiaries {
+		// A zero weight is accepted, as in steemd.
+		v.account("extensions.beneficiaries.account", b.Account)
+		v.check(b.Weight <= Percent100, "extensions.beneficiaries.weight", "must not exceed %d", Percent100)
+		if i > 0 {
+			v.check(beneficiaries[i-1].Account < b.Account, "extensions.beneficiaries",
+				"must be sorted by account without duplicates")
+		}
+		total += int(b.Weight)
+	}
+	v.check(total <= Percent100, "extensions.beneficiaries.weight",
+		"total must not exceed %d", Percent100)
+	return v.Err()
+}
+
+func (op *WitnessUpdateOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("owner", op.Owner)
+	v.check(op.URL != "", "url", "is required")
+	v.check(len(op.URL) <= MaxWitnessURLLength, "url",
+		"must not exceed %d bytes", MaxWitnessURLLength)
+	v.utf8("url", op.URL)
+	v.publicKey("block_signing_key", op.BlockSigningKey)
+	op.Props.validate(v, "props")
+	v.nonNegativeAsset("fee", op.Fee, assetSteem)
+	return v.Err()
+}
+
+func (op *SetWithdrawVestingRouteOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("from_account", op.FromAccount)
+	v.account("to_account", op.ToAccount)
+	v.check(op.Percent <= Percent100, "percent", "must not exceed %d", Percent100)
+	return v.Err()
+}
+
+func (op *LimitOrderCreate2Operation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("owner", op.Owner)
+	validatePrice(v, "exchange_rate", op.ExchangeRate.Base, op.ExchangeRate.Quote)
+	sell := v.positiveAsset("amount_to_sell", op.AmountToSell, assetSteem|assetSBD)
+	base, _ := ParseAsset(op.ExchangeRate.Base)
+	if sell != nil && base != nil {
+		v.check(sell.Symbol == base.Symbol, "amount_to_sell", "must match the exchange rate base")
+	}
+	return v.Err()
+}
+
+func (op *ClaimAccountOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("creator", op.Creator)
+	v.nonNegativeAsset("fee", op.Fee, assetSteem)
+	return v.Err()
+}
+
+func (op *CreateClaimedAccountOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("creator", op.Creator)
+	v.account("new_account_name", op.NewAccountName)
+	v.authority("owner", op.Owner, false)
+	v.authority("active", op.Active, false)
+	v.authority("posting", op.Posting, false)
+	v.publicKey("memo_key", op.MemoKey)
+	v.jsonString("json_metadata", op.JsonMetadata)
+	return v.Err()
+}
+
+func (op *RequestAccountRecoveryOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("recovery_account", op.RecoveryAccount)
+	v.account("account_to_recover", op.AccountToRecover)
+	v.authority("new_owner_authority", op.NewOwnerAuthority, false)
+	return v.Err()
+}
+
+func (op *RecoverAccountOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("account_to_recover", op.AccountToRecover)
+	v.authority("new_owner_authority", op.NewOwnerAuthority, false)
+	v.authority("recent_owner_authority", op.RecentOwnerAuthority, false)
+	if op.NewOwnerAuthority == nil || op.RecentOwnerAuthority == nil {
+		return v.Err()
+	}
+	v.check(!op.NewOwnerAuthority.Equal(op.RecentOwnerAuthority), "new_owner_authority",
+		"must differ from recent_owner_authority")
+	v.check(!op.NewOwnerAuthority.IsImpossible(), "new_owner_authority", "cannot be impossible")
+	v.check(!op.RecentOwnerAuthority.IsImpossible(), "recent_owner_authority", "cannot be impossible")
+	v.check(op.NewOwnerAuthority.WeightThreshold > 0, "new_owner_authority", "weight threshold must be positive")
+	return v.Err()
+}
+
+func (op *ChangeRecoveryAccountOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("account_to_recover", op.AccountToRecover)
+	v.account("new_recovery_account", op.NewRecoveryAccount)
+	return v.Err()
+}
+
+func (op *EscrowTransferOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("from", op.From)
+	v.account("to", op.To)
+	v.account("agent", op.Agent)
+	v.check(op.Agent != op.From && op.Agent != op.To, "agent", "must differ from from and to")
+	v.nonNegativeAsset("fee", op.Fee, assetSteem|assetSBD)
+	sbd := v.nonNegativeAsset("sbd_amount", op.SBDAmount, assetSBD)
+	steem := v.nonNegativeAsset("steem_amount", op.SteemAmount, assetSteem)
+	if sbd != nil && steem != nil {
+		v.check(sbd.Amount+steem.Amount > 0, "steem_amount", "escrow must release a positive amount")
+	}
+	v.timeRange("ratification_deadline", op.RatificationDeadline, op.EscrowExpiration, "escrow_expiration")
+	v.jsonString("json_meta", op.JsonMeta)
+	return v.Err()
+}
+
+func (op *EscrowDisputeOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("from", op.From)
+	v.account("to", op.To)
+	v.account("agent", op.Agent)
+	v.account("who", op.Who)
+	v.check(op.Who == op.From || op.Who == op.To, "who", "must be from or to")
+	return v.Err()
+}
+
+func (op *EscrowReleaseOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("from", op.From)
+	v.account("to", op.To)
+	v.account("agent", op.Agent)
+	v.account("who", op.Who)
+	v.account("receiver", op.Receiver)
+	v.check(op.Who == op.From || op.Who == op.To || op.Who == op.Agent, "who",
+		"must be from, to or agent")
+	v.check(op.Receiver == op.From || op.Receiver == op.To, "receiver", "must be from or to")
+	sbd := v.nonNegativeAsset("sbd_amount", op.SBDAmount, assetSBD)
+	steem := v.nonNegativeAsset("steem_amount", op.SteemAmount, assetSteem)
+	if sbd != nil && steem != nil {
+		v.check(sbd.Amount+steem.Amount > 0, "steem_amount", "escrow must release a positive amount")
+	}
+	return v.Err()
+}
+
+func (op *EscrowApproveOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("from", op.From)
+	v.account("to", op.To)
+	v.account("agent", op.Agent)
+	v.account("who", op.Who)
+	v.check(op.Who == op.To || op.Who == op.Agent, "who", "must be to or agent")
+	return v.Err()
+}
+
+func (op *POW2Operation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.check(op.Input != "", "input", "is required")
+	return v.Err()
+}
+
+func (op *TransferToSavingsOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("from", op.From)
+	v.account("to", op.To)
+	v.positiveAsset("amount", op.Amount, assetSteem|assetSBD)
+	v.memo("memo", op.Memo)
+	return v.Err()
+}
+
+func (op *TransferFromSavingsOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("from", op.From)
+	v.account("to", op.To)
+	v.positiveAsset("amount", op.Amount, assetSteem|assetSBD)
+	v.memo("memo", op.Memo)
+	return v.Err()
+}
+
+func (op *CancelTransferFromSavingsOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("from", op.From)
+	return v.Err()
+}
+
+func (op *CustomBinaryOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.check(len(op.RequiredOwnerAuths)+len(op.RequiredActiveAuths)+len(op.RequiredPostingAuths) > 0,
+		"required_auths", "at least one account must be specified")
+	v.check(len(op.ID) <= MaxCustomIDLength, "id", "must not exceed %d bytes", MaxCustomIDLength)
+	for _, auth := range op.RequiredAuths {
+		v.authority("required_auths", auth, false)
+	}
+	return v.Err()
+}
+
+func (op *CustomJSONOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.check(len(op.RequiredAuths)+len(op.RequiredPostingAuths) > 0, "required_auths",
+		"at least one authority is required")
+	for _, name := range op.RequiredAuths {
+		v.account("required_auths", name)
+	}
+	for _, name := range op.RequiredPostingAuths {
+		v.account("required_posting_auths", name)
+	}
+	v.check(len(op.ID) <= MaxCustomIDLength, "id", "must not exceed %d bytes", MaxCustomIDLength)
+	v.check(op.JSON != "", "json", "is required")
+	v.jsonString("json", op.JSON)
+	return v.Err()
+}
+
+func (op *DeclineVotingRightsOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("account", op.Account)
+	return v.Err()
+}
+
+func (op *ResetAccountOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("reset_account", op.ResetAccount)
+	v.account("account_to_reset", op.AccountToReset)
+	v.authority("new_owner_authority", op.NewOwnerAuthority, false)
+	return v.Err()
+}
+
+func (op *SetResetAccountOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("account", op.Account)
+	v.optionalAccount("current_reset_account", op.CurrentResetAccount)
+	v.account("reset_account", op.ResetAccount)
+	v.check(op.CurrentResetAccount != op.ResetAccount, "reset_account",
+		"must differ from current_reset_account")
+	return v.Err()
+}
+
+func (op *ClaimRewardBalanceOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("account", op.Account)
+	steem := v.nonNegativeAsset("reward_steem", op.RewardSteem, assetSteem)
+	sbd := v.nonNegativeAsset("reward_sbd", op.RewardSBD, assetSBD)
+	vests := v.nonNegativeAsset("reward_vests", op.RewardVests, assetVests)
+	if steem != nil && sbd != nil && vests != nil {
+		v.check(steem.Amount+sbd.Amount+vests.Amount > 0, "reward_vests", "must claim a non-zero amount")
+	}
+	return v.Err()
+}
+
+func (op *DelegateVestingSharesOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("delegator", op.Delegator)
+	v.account("delegatee", op.Delegatee)
+	v.check(op.Delegator != op.Delegatee, "delegatee", "cannot delegate to self")
+	v.nonNegativeAsset("vesting_shares", op.VestingShares, assetVests)
+	return v.Err()
+}
+
+func (op *AccountCreateWithDelegationOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("creator", op.Creator)
+	v.account("new_account_name", op.NewAccountName)
+	v.nonNegativeAsset("fee", op.Fee, assetSteem)
+	v.nonNegativeAsset("delegation", op.Delegation, assetVests)
+	v.authority("owner", op.Owner, false)
+	v.authority("active", op.Active, false)
+	v.authority("posting", op.Posting, false)
+	v.publicKey("memo_key", op.MemoKey)
+	v.jsonString("json_metadata", op.JsonMetadata)
+	return v.Err()
+}
+
+func (op *WitnessSetPropertiesOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("owner", op.Owner)
+	_, ok := op.Props["key"]
+	v.check(ok, "props", "must contain the signing key")
+	for name, value := range op.Props {
+		raw, err := hex.DecodeString(value)
+		if err != nil {
+			v.fail("props."+name, "must be hex encoded")
+			continue
+		}
+		validateWitnessProp(v, "props."+name, name, raw)
+	}
+	return v.Err()
+}
+
+// validateWitnessProp checks the packed value of a known witness property
+// like witness_set_properties_operation::validate. Unknown properties are
+// ignored, as in steemd.
+func validateWitnessProp(v *opValidator, field, name string, raw []byte) {
+	switch name {
+	case "account_creation_fee":
+		fee, ok := unpackAsset(v, field, raw)
+		if !ok {
+			return
+		}
+		for _, cfg := range consts.KnownChains {
+			if fee.Symbol == cfg.SteemSymbol && fee.Precision == cfg.SteemPrecision {
+				v.check(fee.Amount >= cfg.MinAccountCreationFee, field,
+					"must be at least the minimum account creation fee")
+				return
+			}
+		}
+		v.fail(field, "must be in STEEM")
+	case "maximum_block_size":
+		size, ok := unpackUint(v, field, raw, 4)
+		v.check(!ok || size >= MinBlockSizeLimit, field, "must be at least %d", MinBlockSizeLimit)
+	case "sbd_interest_rate":
+		rate, ok := unpackUint(v, field, raw, 2)
+		v.check(!ok || rate <= Percent100, field, "must not exceed %d", Percent100)
+	case "new_signing_key":
+		if len(raw) < 33 {
+			v.fail(field, "must be a public key")
+			return
+		}
+		if err := (&wif.PublicKey{}).FromByte(raw[:33]); err != nil {
+			v.fail(field, "must be a public key")
+		}
+	case "sbd_exchange_rate":
+		if len(raw) < 32 {
+			v.fail(field, "must be a price")
+			return
+		}
+		base, _ := unpackAsset(v, field, raw[:16])
+		quote, _ := unpackAsset(v, field, raw[16:32])
+		if base == nil || quote == nil {
+			return
+		}
+		v.check(base.Precision == 3 && isCoreSymbol(base.Symbol, func(cfg *consts.ChainConfig) string { return cfg.SBDSymbol }) &&
+			quote.Precision == 3 && isCoreSymbol(quote.Symbol, func(cfg *consts.ChainConfig) string { return cfg.SteemSymbol }),
+			field, "must be a SBD/STEEM price")
+		v.check(base.Amount > 0 && quote.Amount > 0, field, "amounts must be positive")
+	case "url":
+		size, n := binary.Uvarint(raw)
+		if n <= 0 || uint64(len(raw)-n) < size {
+			v.fail(field, "must be a string")
+			return
+		}
+		url := raw[n : n+int(size)]
+		v.check(len(url) > 0, field, "is required")
+		v.check(len(url) <= MaxWitnessURLLength, field, "must not exceed %d bytes", MaxWitnessURLLength)
+		v.check(utf8.Valid(url), field, "must be valid UTF-8")
+	case "account_subsidy_budget":
+		budget, ok := unpackUint(v, field, raw, 4)
+		v.check(!ok || int32(budget) >= MinAccountSubsidyBudget && int32(budget) <= MaxAccountSubsidyBudget, field,
+			"must be between %d and %d", MinAccountSubsidyBudget, MaxAccountSubsidyBudget)
+	case "account_subsidy_decay":
+		decay, ok := unpackUint(v, field, raw, 4)
+		v.check(!ok || decay >= MinAccountSubsidyDecay && decay <= MaxAccountSubsidyDecay, field,
+			"must be between %d and %d", MinAccountSubsidyDecay, MaxAccountSubsidyDecay)
+	}
+}
+
+// unpackUint reads a little endian integer of size bytes.
+func unpackUint(v *opValidator, field string, raw []byte, size int) (uint64, bool) {
+	if len(raw) < size {
+		v.fail(field, "must be a %d byte integer", size)
+		return 0, false
+	}
+	var buf [8]byte
+	copy(buf[:], raw[:size])
+	return binary.LittleEndian.Uint64(buf[:]), true
+}
+
+// unpackAsset reads a legacy binary asset.
+func unpackAsset(v *opValidator, field string, raw []byte) (*Asset, bool) {
+	a, err := UnmarshalAsset(raw)
+	if err != nil {
+		v.fail(field, "must be an asset")
+		return nil, false
+	}
+	return a, true
+}
+
+// isCoreSymbol reports whether symbol is the given core symbol of a known
+// chain.
+func isCoreSymbol(symbol string, core func(cfg *consts.ChainConfig) string) bool {
+	for _, cfg := range consts.KnownChains {
+		if symbol == core(cfg) {
+			return true
+		}
+	}
+	return false
+}
+
+func (op *AccountUpdate2Operation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("account", op.Account)
+	v.authority("owner", op.Owner, true)
+	v.authority("active", op.Active, true)
+	v.authority("posting", op.Posting, true)
+	if op.MemoKey != "" {
+		v.publicKey("memo_key", op.MemoKey)
+	}
+	v.jsonString("json_metadata", op.JsonMetadata)
+	v.jsonString("posting_json_metadata", op.PostingJsonMetadata)
+	return v.Err()
+}
+
+func (op *CreateProposalOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("creator", op.Creator)
+	v.account("receiver", op.Receiver)
+	v.timeRange("start_date", op.StartDate, op.EndDate, "end_date")
+	v.nonNegativeAsset("daily_pay", op.DailyPay, assetSBD)
+	v.check(op.Subject != "", "subject", "is required")
+	v.check(len(op.Subject) <= MaxProposalSubjectLength, "subject",
+		"must not exceed %d bytes", MaxProposalSubjectLength)
+	v.utf8("subject", op.Subject)
+	v.permlink("permlink", op.Permlink)
+	return v.Err()
+}
+
+func (op *UpdateProposalVotesOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("voter", op.Voter)
+	validateProposalIDs(v, op.ProposalIDs)
+	return v.Err()
+}
+
+func (op *RemoveProposalOperation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("proposal_owner", op.ProposalOwner)
+	validateProposalIDs(v, op.ProposalIDs)
+	return v.Err()
+}
+
+func (op *ClaimRewardBalance2Operation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("account", op.Account)
+	return v.Err()
+}
+
+func (op *Vote2Operation) Validate() error {
+	v := newOpValidator(op.Type())
+	v.account("voter", op.Voter)
+	v.account("author", op.Author)
+	v.permlink("permlink", op.Permlink)
+	return v.Err()
+}
+
+// validatePrice checks a STEEM/SBD price: positive amounts of STEEM and SBD
+// in either order. Field prefixes the base and quote field names.
+func validatePrice(v *opValidator, field, base, quote string) {
+	baseField, quoteField := "amount_to_sell", "min_to_receive"
+	if field != "" {
+		baseField, quoteField = field+".base", field+".quote"
+	}
+	b := v.positiveAsset(baseField, base, assetSteem|assetSBD)
+	q := v.positiveAsset(quoteField, quote, assetSteem|assetSBD)
+	if b != nil && q != nil {
+		v.check(b.Symbol != q.Symbol, quoteField, "must differ from %s", baseField)
+	}
+}
+
+func validateProposalIDs(v *opValidator, ids []uint64) {
+	v.check(len(ids) > 0, "proposal_ids", "is required")
+	v.check(len(ids) <= MaxProposalIDs, "proposal_ids", "must not exceed %d entries", MaxProposalIDs)
+}
+
+// Virtual operations are produced by the chain and cannot be broadcast, so
+// their Validate always fails, as in steemd.
+
+func validateVirtual(kind OpType) error {
+	return &FieldError{Op: kind, Reason: "virtual operation cannot be broadcast"}
+}
+
+func (op *FillConvertRequestOperation) Validate() error      { return validateVirtual(op.Type()) }
+func (op *CommentRewardOperation) Validate() error           { return validateVirtual(op.Type()) }
+func (op *LiquidityRewardOperation) Validate() error         { return validateVirtual(op.Type()) }
+func (op *InterestOperation) Validate() error                { return validateVirtual(op.Type()) }
+func (op *FillVestingWithdrawOperation) Validate() error     { return validateVirtual(op.Type()) }
+func (op *FillOrderOperation) Validate() error               { return validateVirtual(op.Type()) }
+func (op *FillTransferFromSavingsOperation) Validate() error { return validateVirtual(op.Type()) }
+func (op *AuthorRewardOperation) Validate() error            { return validateVirtual(op.Type()) }
+func (op *CurationRewardOperation) Validate() error          { return validateVirtual(op.Type()) }
+func (op *ShutdownWitnessOperation) Validate() error         { return validateVirtual(op.Type()) }
+func (op *HardforkOperation) Validate() error                { return validateVirtual(op.Type()) }
+func (op *CommentPayoutUpdateOperation) Validate() error     { return validateVirtual(op.Type()) }
+func (op *ReturnVestingDelegationOperation) Validate() error { return validateVirtual(op.Type()) }
+func (op *CommentBenefactorRewardOperation) Validate() error { return validateVirtual(op.Type()) }
+func (op *ProducerRewardOperation) Validate() error          { return validateVirtual(op.Type()) }
+func (op *ClearNullAccountBalanceOperation) Validate() error { return validateVirtual(op.Type()) }
+func (op *ProposalPayOperation) Validate() error             { return validateVirtual(op.Type()) }
+func (op *SPSFundOperation) Validate() error                 { return validateVirtual(op.Type()) }
@@ -1,11 +1,48 @@
 package protocol
 
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/encoder"
+)
+
 // OpType represents a Steem operation type, i.e. vote, comment, pow and so on.
 type OpType string
 
 // Code returns the operation code associated with the given operation type.
-func (kind OpType) Code() uint16 {
-	return opCodes[kind]
+// It fails for types steemd does not know.
+func (kind OpType) Code() (uint16, error) {
+	if code, ok := opCodes[kind]; ok {
+		return code, nil
+	}
+	if code, ok := legacyOpCodes[kind]; ok {
+		return code, nil
+	}
+	if _, ok := unsupportedOpTypes[kind]; ok {
+		return 0, errors.Errorf("operation %s is not part of the steemd operation set", kind)
+	}
+	return 0, errors.Errorf("unknown operation type: %s", kind)
+}
+
+// encodeOpCode writes the operation code that starts a serialized operation.
+func encodeOpCode(encoderObj *encoder.Encoder, kind OpType) error {
+	code, err := kind.Code()
+	if err != nil {
+		return err
+	}
+	if err := encoderObj.EncodeUVarint(uint64(code)); err != nil {
+		return errors.Wrap(err, "failed to encode operation type code")
+	}
+	return nil
+}
+
+// OpTypeFromCode returns the operation type of an operation code.
+func OpTypeFromCode(code uint16) (OpType, error) {
+	if kind, ok := opTypesByCode[code]; ok {
+		return kind, nil
+	}
+	return "", errors.Errorf("unknown operation code: %d", code)
 }
 
 const (
@@ -67,72 +104,116 @@ const (
 	TypeFillVestingWithdraw         OpType = "fill_vesting_withdraw"
 	TypeFillOrder                   OpType = "fill_order"
 	TypeFillTransferFromSavings     OpType = "fill_transfer_from_savings"
+	TypeAuthorReward                OpType = "author_reward"
+	TypeCurationReward              OpType = "curation_reward"
+	TypeShutdownWitness             OpType = "shutdown_witness"
+	TypeHardfork                    OpType = "hardfork"
+	TypeCommentPayoutUpdate         OpType = "comment_payout_update"
+	TypeReturnVestingDelegation     OpType = "return_vesting_delegation"
+	TypeCommentBenefactorReward     OpType = "comment_benefactor_reward"
+	TypeProducerReward              OpType = "producer_reward"
+	TypeClearNullAccountBalance     OpType = "clear_null_account_balance"
+	TypeProposalPay                 OpType = "proposal_pay"
+	TypeSPSFund                     OpType = "sps_fund"
 )
 
-var opTypes = [...]OpType{
-	TypeVote,
-	TypeComment,
-	TypeTransfer,
-	TypeTransferToVesting,
-	TypeWithdrawVesting,
-	TypeLimitOrderCreate,
-	TypeLimitOrderCancel,
-	TypeFeedPublish,
-	TypeConvert,
-	TypeAccountCreate,
-	TypeAccountUpdate,
-	TypeWitnessUpdate,
-	TypeAccountWitnessVote,
-	TypeAccountWitnessProxy,
-	TypePOW,
-	TypeCustom,
-	TypeReportOverProduction,
-	TypeDeleteComment,
-	TypeCustomJSON,
-	TypeCommentOptions,
-	TypeSetWithdrawVestingRoute,
-	TypeLimitOrderCreate2,
-	TypeClaimAccount,
-	TypeCreateClaimedAccount,
-	TypeRequestAccountRecovery,
-	TypeRecoverAccount,
-	TypeChangeRecoveryAccount,
-	TypeEscrowTransfer,
-	TypeEscrowDispute,
-	TypeEscrowRelease,
-	TypePOW2,
-	TypeEscrowApprove,
-	TypeTransferToSavings,
-	TypeTransferFromSavings,
-	TypeCancelTransferFromSavings,
-	TypeCustomBinary,
-	TypeDeclineVotingRights,
-	TypeResetAccount,
-	TypeSetResetAccount,
-	TypeClaimRewardBalance,
-	TypeDelegateVestingShares,
-	TypeAccountCreateWithDelegation,
-	TypeWitnessSetProperties,
-	TypeAccountUpdate2,
-	TypeCreateProposal,
-	TypeUpdateProposalVotes,
-	TypeRemoveProposal,
-	TypeClaimRewardBalance2,
-	TypeFillConvertRequest,
-	TypeCommentReward,
-	TypeLiquidityReward,
-	TypeInterest,
-	TypeFillVestingWithdraw,
-	TypeFillOrder,
-	TypeFillTransferFromSavings,
+// opCodes keeps mapping operation type -> operation code. The code is the
+// position of the operation in the steemd operation static_variant
+// (libraries/protocol/include/steem/protocol/operations.hpp).
+var opCodes = map[OpType]uint16{
+	TypeVote:                        0,
+	TypeComment:                     1,
+	TypeTransfer:                    2,
+	TypeTransferToVesting:           3,
+	TypeWithdrawVesting:             4,
+	TypeLimitOrderCreate:            5,
+	TypeLimitOrderCancel:            6,
+	TypeFeedPublish:                 7,
+	TypeConvert:                     8,
+	TypeAccountCreate:               9,
+	TypeAccountUpdate:               10,
+	TypeWitnessUpdate:               11,
+	TypeAccountWitnessVote:          12,
+	TypeAccountWitnessProxy:         13,
+	TypePOW:                         14,
+	TypeCustom:                      15,
+	TypeReportOverProduction:        16,
+	TypeDeleteComment:               17,
+	TypeCustomJSON:                  18,
+	TypeCommentOptions:              19,
+	TypeSetWithdrawVestingRoute:     20,
+	TypeLimitOrderCreate2:           21,
+	TypeClaimAccount:                22,
+	TypeCreateClaimedAccount:        23,
+	TypeRequestAccountRecovery:      24,
+	TypeRecoverAccount:              25,
+	TypeChangeRecoveryAccount:       26,
+	TypeEscrowTransfer:              27,
+	TypeEscrowDispute:               28,
+	TypeEscrowRelease:               29,
+	TypePOW2:                        30,
+	TypeEscrowApprove:               31,
+	TypeTransferToSavings:           32,
+	TypeTransferFromSavings:         33,
+	TypeCancelTransferFromSavings:   34,
+	TypeCustomBinary:                35,
+	TypeDeclineVotingRights:         36,
+	TypeResetAccount:                37,
+	TypeSetResetAccount:             38,
+	TypeClaimRewardBalance:          39,
+	TypeDelegateVestingShares:       40,
+	TypeAccountCreateWithDelegation: 41,
+	TypeWitnessSetProperties:        42,
+	TypeAccountUpdate2:              43,
+	TypeCreateProposal:              44,
+	TypeUpdateProposalVotes:         45,
+	TypeRemoveProposal:              46,
+
+	// Virtual operations.
+	TypeFillConvertRequest:      47,
+	TypeAuthorReward:            48,
+	TypeCurationReward:          49,
+	TypeCommentReward:           50,
+	TypeLiquidityReward:         51,
+	TypeInterest:                52,
+	TypeFillVestingWithdraw:     53,
+	TypeFillOrder:               54,
+	TypeShutdownWitness:         55,
+	TypeFillTransferFromSavings: 56,
+	TypeHardfork:                57,
+	TypeCommentPayoutUpdate:     58,
+	TypeReturnVestingDelegation: 59,
+	TypeCommentBenefactorReward: 60,
+	TypeProducerReward:          61,
+	TypeClearNullAccountBalance: 62,
+	TypeProposalPay:             63,
+	TypeSPSFund:                 64,
+}
+
+// legacyOpCodes keeps the codes of operations removed from steemd. They are
+// only valid in blocks before the removal; the codes now belong to other
+// operations.
+var legacyOpCodes = map[OpType]uint16{
+	TypeChallengeAuthority: 22, // replaced by claim_account in HF20
+	TypeProveAuthority:     23, // replaced by create_claimed_account in HF20
+}
+
+// unsupportedOpTypes are declared for JSON decoding but only exist in SMT
+// builds of steemd, so they have no code on the Steem networks.
+var unsupportedOpTypes = map[OpType]struct{}{
+	TypeVote2:               {},
+	TypeClaimRewardBalance2: {},
 }
 
-// opCodes keeps mapping operation type -> operation code.
-var opCodes map[OpType]uint16
+// opTypesByCode keeps mapping operation code -> operation type.
+var opTypesByCode map[uint16]OpType
 
 func init() {
-	opCodes = make(map[OpType]uint16, len(opTypes))
-	for i, opType := range opTypes {
-		opCodes[opType] = uint16(i)
+	opTypesByCode = make(map[uint16]OpType, len(opCodes))
+	for opType, code := range opCodes {
+		if other, ok := opTypesByCode[code]; ok {
+			panic("protocol: operation code " + strconv.Itoa(int(code)) + " used by " + string(opType) + " and " + string(other))
+		}
+		opTypesByCode[code] = opType
 	}
 }
@@ -0,0 +1,140 @@
+package protocol
+
+import "testing"
+
+// steemdOpCodes pins the codes of the steemd operation static_variant.
+var steemdOpCodes = []OpType{
+	"vote",
+	"comment",
+	"transfer",
+	"transfer_to_vesting",
+	"withdraw_vesting",
+	"limit_order_create",
+	"limit_order_cancel",
+	"feed_publish",
+	"convert",
+	"account_create",
+	"account_update",
+	"witness_update",
+	"account_witness_vote",
+	"account_witness_proxy",
+	"pow",
+	"custom",
+	"report_over_production",
+	"delete_comment",
+	"custom_json",
+	"comment_options",
+	"set_withdraw_vesting_route",
+	"limit_order_create2",
+	"claim_account",
+	"create_claimed_account",
+	"request_account_recovery",
+	"recover_account",
+	"change_recovery_account",
+	"escrow_transfer",
+	"escrow_dispute",
+	"escrow_release",
+	"pow2",
+	"escrow_approve",
+	"transfer_to_savings",
+	"transfer_from_savings",
+	"cancel_transfer_from_savings",
+	"custom_binary",
+	"decline_voting_rights",
+	"reset_account",
+	"set_reset_account",
+	"claim_reward_balance",
+	"delegate_vesting_shares",
+	"account_create_with_delegation",
+	"witness_set_properties",
+	"account_update2",
+	"create_proposal",
+	"update_proposal_votes",
+	"remove_proposal",
+	"fill_convert_request",
+	"author_reward",
+	"curation_reward",
+	"comment_reward",
+	"liquidity_reward",
+	"interest",
+	"fill_vesting_withdraw",
+	"fill_order",
+	"shutdown_witness",
+	"fill_transfer_from_savings",
+	"hardfork",
+	"comment_payout_update",
+	"return_vesting_delegation",
+	"comment_benefactor_reward",
+	"producer_reward",
+	"clear_null_account_balance",
+	"proposal_pay",
+	"sps_fund",
+}
+
+func TestOpTypeCodes(t *testing.T) {
+	if len(opCodes) != len(steemdOpCodes) {
+		t.Errorf("expected %d codes, got %d", len(steemdOpCodes), len(opCodes))
+	}
+	for i, kind := range steemdOpCodes {
+		code, err := kind.Code()
+		if err != nil {
+			t.Errorf("%s: %v", kind, err)
+			continue
+		}
+		if code != uint16(i) {
+			t.Errorf("%s: expected %d, got %d", kind, i, code)
+		}
+
+		got, err := OpTypeFromCode(uint16(i))
+		if err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		if got != kind {
+			t.Errorf("%d: expected %s, got %s", i, kind, got)
+		}
+	}
+}
+
+func TestOpTypeLegacyCodes(t *testing.T) {
+	testCases := map[OpType]uint16{
+		TypeChallengeAuthority: 22,
+		TypeProveAuthority:     23,
+	}
+	for kind, expected := range testCases {
+		code, err := kind.Code()
+		if err != nil {
+			t.Errorf("%s: %v", kind, err)
+		}
+		if code != expected {
+			t.Errorf("%s: expected %d, got %d", kind, expected, code)
+		}
+	}
+
+	// The codes now belong to the HF20 operations.
+	if kind, _ := OpTypeFromCode(22); kind != TypeClaimAccount {
+		t.Errorf("expected %s, got %s", TypeClaimAccount, kind)
+	}
+}
+
+func TestOpTypeUnknownCodes(t *testing.T) {
+	for _, kind := range []OpType{TypeVote2, TypeClaimRewardBalance2, "no_such_operation"} {
+		if _, err := kind.Code(); err == nil {
+			t.Errorf("expected an error for %s", kind)
+		}
+	}
+	if _, err := OpTypeFromCode(uint16(len(steemdOpCodes))); err == nil {
+		t.Error("expected an error for an unknown code")
+	}
+}
+
+func TestOpTypeCodesHaveDataObjects(t *testing.T) {
+	for kind := range opCodes {
+		if kind == TypeCustom {
+			continue
+		}
+		if _, ok := dataObjects[kind]; !ok {
+			t.Errorf("no data object for %s", kind)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package protocol
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/consts"
+	"github.com/steemit/steemutil/wif"
+)
+
+// Protocol limits checked by the operation validators, as in steemd.
+const (
+	MaxCustomIDLength        = 32
+	MaxWitnessURLLength      = 2048
+	MinBlockSizeLimit        = 1024 * 64
+	MaxProposalSubjectLength = 80
+	MaxProposalIDs           = 5
+	MaxCommentBeneficiaries  = 8
+	MaxBeneficiaryRoutes     = 127
+	Percent100               = 10000
+
+	// Bounds of the resource dynamics parameters set by witnesses.
+	MinAccountSubsidyBudget = 1
+	MaxAccountSubsidyBudget = 1<<28 - 1
+	MinAccountSubsidyDecay  = 1 << 6
+	MaxAccountSubsidyDecay  = 1<<32 - 1
+)
+
+// FieldError reports an operation field violating a chain rule. Field is the
+// JSON name of the field, dotted for nested fields. It is empty for errors
+// about the operation as a whole.
+type FieldError struct {
+	Op     OpType
+	Field  string
+	Reason string
+}
+
+func (e *FieldError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s: %s", e.Op, e.Reason)
+	}
+	return fmt.Sprintf("%s: invalid %s: %s", e.Op, e.Field, e.Reason)
+}
+
+// ValidateOperation calls the Validate method of op. Operations without one,
+// which are unknown to this package, are rejected.
+func ValidateOperation(op Operation) error {
+	if v, ok := op.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+	return &FieldError{Op: op.Type(), Reason: "unknown operation"}
+}
+
+// Asset classes accepted by opValidator.asset.
+const (
+	assetSteem = 1 << iota
+	assetSBD
+	assetVests
+)
+
+// opValidator records the first rule violated by an operation, so that
+// Validate methods read as a list of checks.
+type opValidator struct {
+	op  OpType
+	err *FieldError
+}
+
+func newOpValidator(op OpType) *opValidator {
+	return &opValidator{op: op}
+}
+
+// Err returns the first violation, or nil.
+func (v *opValidator) Err() error {
+	if v.err == nil {
+		return nil
+	}
+	return v.err
+}
+
+func (v *opValidator) fail(field, format string, args ...interface{}) {
+	if v.err == nil {
+		v.err = &FieldError{Op: v.op, Field: field, Reason: fmt.Sprintf(format, args...)}
+	}
+}
+
+func (v *opValidator) check(ok bool, field, format string, args ...interface{}) {
+	if !ok {
+		v.fail(field, format, args...)
+	}
+}
+
+func (v *opValidator) account(field, name string) {
+	var nameErr *AccountNameError
+	if errors.As(ValidateAccountName(name), &nameErr) {
+		v.fail(field, "%q is not a valid account name: %v", name, nameErr.Reason)
+	}
+}
+
+// optionalAccount accepts an empty name.
+func (v *opValidator) optionalAccount(field, name string) {
+	if name != "" {
+		v.account(field, name)
+	}
+}
+
+// permlink checks the rules of steemd's validate_permlink: shorter than
+// MaxPermlinkLength bytes and valid UTF-8. Existing permlinks may use any
+// characters, see IsCanonicalPermlink for the front-end charset.
+func (v *opValidator) permlink(field, permlink string) {
+	if len(permlink) >= consts.SteemMainnet.MaxPermlinkLength {
+		v.fail(field, "must be shorter than %d bytes", consts.SteemMainnet.MaxPermlinkLength)
+		return
+	}
+	v.utf8(field, permlink)
+}
+
+func (v *opValidator) utf8(field, s string) {
+	if !utf8.ValidString(s) {
+		v.fail(field, "must be valid UTF-8")
+	}
+}
+
+func (v *opValidator) memo(field, memo string) {
+	v.check(len(memo) < consts.SteemMainnet.MaxMemoSize, field,
+		"must be shorter than %d bytes", consts.SteemMainnet.MaxMemoSize)
+	v.utf8(field, memo)
+}
+
+// jsonString accepts an empty string or valid JSON.
+func (v *opValidator) jsonString(field, s string) {
+	if s == "" {
+		return
+	}
+	v.utf8(field, s)
+	v.check(json.Valid([]byte(s)), field, "must be valid JSON")
+}
+
+// asset parses a legacy asset string and checks its symbol is one of the
+// given classes on a known chain, with the precision of that chain. It
+// returns nil when the asset is invalid.
+func (v *opValidator) asset(field, s string, classes int) *Asset {
+	a, err := ParseAsset(s)
+	if err != nil {
+		v.fail(field, "%q is not a valid asset", s)
+		return nil
+	}
+	for _, cfg := range consts.KnownChains {
+		for _, c := range []struct {
+			class     int
+			symbol    string
+			precision uint8
+		}{
+			{assetSteem, cfg.SteemSymbol, cfg.SteemPrecision},
+			{assetSBD, cfg.SBDSymbol, cfg.SBDPrecision},
+			{assetVests, cfg.VestsSymbol, cfg.VestsPrecision},
+		} {
+			if a.Symbol != c.symbol {
+				continue
+			}
+			if classes&c.class == 0 {
+				break
+			}
+			if a.Precision != c.precision {
+				v.fail(field, "%s must have precision %d", a.Symbol, c.precision)
+				return nil
+			}
+			return a
+		}
+	}
+	v.fail(field, "symbol must be %s, got %s", assetClassNames(classes), a.Symbol)
+	return nil
+}
+
+// positiveAsset also requires an amount above zero.
+func (v *opValidator) positiveAsset(field, s string, classes int) *Asset {
+	a := v.asset(field, s, classes)
+	if a != nil && a.Amount <= 0 {
+		v.fail(field, "must be positive")
+	}
+	return a
+}
+
+// nonNegativeAsset also requires an amount of zero or more.
+func (v *opValidator) nonNegativeAsset(field, s string, classes int) *Asset {
+	a := v.asset(field, s, classes)
+	if a != nil && a.Amount < 0 {
+		v.fail(field, "must not be negative")
+	}
+	return a
+}
+
+func assetClassNames(classes int) string {
+	var names []string
+	if classes&assetSteem != 0 {
+		names = append(names, "STEEM")
+	}
+	if classes&assetSBD != 0 {
+		names = append(names, "SBD")
+	}
+	if classes&assetVests != 0 {
+		names = append(names, "VESTS")
+	}
+	return strings.Join(names, " or ")
+}
+
+// publicKey checks s is a public key with the address prefix of a known
+// chain.
+func (v *opValidator) publicKey(field, s string) {
+	for _, cfg := range consts.KnownChains {
+		if _, err := wif.ParsePublicKey(s, cfg); err == nil {
+			return
+		}
+	}
+	v.fail(field, "%q is not a valid public key", s)
+}
+
+// authority checks the account and key names of auth; nil is rejected
+// unless optional is set.
+func (v *opValidator) authority(field string, auth *Authority, optional bool) {
+	if auth == nil {
+		v.check(optional, field, "is required")
+		return
+	}
+	for name := range auth.AccountAuths {
+		v.account(field+".account_auths", name)
+	}
+	for key := range auth.KeyAuths {
+		v.publicKey(field+".key_auths", key)
+	}
+}
+
+// timeRange requires start to be set and before end.
+func (v *opValidator) timeRange(startField string, start, end *Time, endField string) {
+	switch {
+	case start == nil || start.Time == nil:
+		v.fail(startField, "is required")
+	case end == nil || end.Time == nil:
+		v.fail(endField, "is required")
+	case !start.Time.Before(*end.Time):
+		v.fail(endField, "must be after %s", startField)
+	}
+}
@@ -0,0 +1,126 @@
+package protocol
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEveryOperationValidates(t *testing.T) {
+	for kind, op := range dataObjects {
+		if _, ok := op.(interface{ Validate() error }); !ok {
+			t.Errorf("expected %s to implement Validate", kind)
+		}
+	}
+	if err := ValidateOperation(&UnknownOperation{kind: "foo"}); err == nil {
+		t.Error("expected unknown operations to be rejected")
+	}
+	if err := ValidateOperation(&AuthorRewardOperation{}); err == nil {
+		t.Error("expected virtual operations to be rejected")
+	}
+}
+
+func TestOperationValidate(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	key := "STM8m5UgaFAAYQRuaNejYdS8FVLVp9Ss3K1qAVk5de6F8s3HnVbvA"
+	owner := &Authority{KeyAuths: StringInt64Map{key: 1}, WeightThreshold: 1}
+	other := &Authority{AccountAuths: StringInt64Map{"bob": 1}, WeightThreshold: 1}
+	impossible := &Authority{KeyAuths: StringInt64Map{key: 1}, WeightThreshold: 2}
+	beneficiaries := func(bs ...Beneficiary) []any {
+		return []any{NewBeneficiariesExtension(bs)}
+	}
+	witnessProps := func(name, value string) StringBytesMap {
+		return StringBytesMap{"key": "02" + strings.Repeat("00", 32), name: value}
+	}
+	tests := []struct {
+		name  string
+		op    interface{ Validate() error }
+		field string // empty when the operation is valid
+	}{
+		{"valid vote", &VoteOperation{Voter: "alice", Author: "bob", Permlink: "my-post", Weight: 10000}, ""},
+		{"vote weight", &VoteOperation{Voter: "alice", Author: "bob", Permlink: "my-post", Weight: 10001}, "weight"},
+		{"vote voter", &VoteOperation{Voter: "Alice", Author: "bob", Permlink: "my-post"}, "voter"},
+		{"vote permlink", &VoteOperation{Voter: "alice", Author: "bob", Permlink: "my-post\xff"}, "permlink"},
+		{"vote legacy permlink", &VoteOperation{Voter: "alice", Author: "bob", Permlink: "My_Post", Weight: 10000}, ""},
+		{"vote non-ASCII permlink", &VoteOperation{Voter: "alice", Author: "bob", Permlink: "한국어-게시물", Weight: 10000}, ""},
+		{"reply to legacy permlink", &CommentOperation{ParentAuthor: "bob", ParentPermlink: "Re_My_Post", Author: "alice", Permlink: "re-bob", Body: "b"}, ""},
+		{"long permlink", &DeleteCommentOperation{Author: "bob", Permlink: strings.Repeat("a", 256)}, "permlink"},
+		{"valid transfer", &TransferOperation{From: "alice", To: "bob", Amount: "1.000 SBD", Memo: "hi"}, ""},
+		{"testnet transfer", &TransferOperation{From: "alice", To: "bob", Amount: "1.000 TESTS"}, ""},
+		{"transfer vests", &TransferOperation{From: "alice", To: "bob", Amount: "1.000000 VESTS"}, "amount"},
+		{"transfer precision", &TransferOperation{From: "alice", To: "bob", Amount: "1.0 STEEM"}, "amount"},
+		{"transfer zero", &TransferOperation{From: "alice", To: "bob", Amount: "0.000 STEEM"}, "amount"},
+		{"transfer memo", &TransferOperation{From: "alice", To: "bob", Amount: "1.000 STEEM", Memo: strings.Repeat("m", 2048)}, "memo"},
+		{"vesting sbd", &TransferToVestingOperation{From: "alice", Amount: "1.000 SBD"}, "amount"},
+		{"valid vesting", &TransferToVestingOperation{From: "alice", Amount: "1.000 STEEM"}, ""},
+		{"comment options", &CommentOptionsOperation{Author: "bob", Permlink: "p", MaxAcceptedPayout: "1000000.000 SBD", PercentSteemDollars: 10001}, "percent_steem_dollars"},
+		{"comment metadata", &CommentOperation{Author: "bob", ParentPermlink: "steem", Permlink: "p", Body: "b", JsonMetadata: "{"}, "json_metadata"},
+		{"comment body", &CommentOperation{Author: "bob", ParentPermlink: "steem", Permlink: "p"}, "body"},
+		{"valid comment", &CommentOperation{Author: "bob", ParentPermlink: "steem", Permlink: "p", Body: "b", JsonMetadata: `{"tags":["steem"]}`}, ""},
+		{"proxy self", &AccountWitnessProxyOperation{Account: "alice", Proxy: "alice"}, "proxy"},
+		{"custom json auths", &CustomJSONOperation{ID: "follow", JSON: "{}"}, "required_auths"},
+		{"custom json body", &CustomJSONOperation{RequiredPostingAuths: []string{"alice"}, ID: "follow", JSON: "[1,"}, "json"},
+		{"feed symbols", &FeedPublishOperation{Publisher: "alice"}, "exchange_rate.base"},
+		{"limit order", &LimitOrderCreateOperation{Owner: "alice", AmountToSell: "1.000 STEEM", MinToReceive: "1.000 STEEM"}, "min_to_receive"},
+		{"delegate self", &DelegateVestingSharesOperation{Delegator: "alice", Delegatee: "alice", VestingShares: "1.000000 VESTS"}, "delegatee"},
+		{"claim nothing", &ClaimRewardBalanceOperation{Account: "alice", RewardSteem: "0.000 STEEM", RewardSBD: "0.000 SBD", RewardVests: "0.000000 VESTS"}, "reward_vests"},
+		{"escrow dates", &EscrowTransferOperation{From: "alice", To: "bob", Agent: "carol", Fee: "0.001 STEEM", SBDAmount: "1.000 SBD", SteemAmount: "0.000 STEEM",
+			RatificationDeadline: &Time{&end}, EscrowExpiration: &Time{&start}}, "escrow_expiration"},
+		{"proposal ids", &UpdateProposalVotesOperation{Voter: "alice", ProposalIDs: []uint64{1, 2, 3, 4, 5, 6}}, "proposal_ids"},
+		{"authority key", &AccountUpdateOperation{Account: "alice", Posting: &Authority{KeyAuths: StringInt64Map{"STMbad": 1}}}, "posting.key_auths"},
+		{"valid recover", &RecoverAccountOperation{AccountToRecover: "alice", NewOwnerAuthority: owner, RecentOwnerAuthority: other}, ""},
+		{"recover same authority", &RecoverAccountOperation{AccountToRecover: "alice", NewOwnerAuthority: owner,
+			RecentOwnerAuthority: &Authority{KeyAuths: StringInt64Map{key: 1}, WeightThreshold: 1}}, "new_owner_authority"},
+		{"recover impossible", &RecoverAccountOperation{AccountToRecover: "alice", NewOwnerAuthority: owner, RecentOwnerAuthority: impossible}, "recent_owner_authority"},
+		{"custom binary auths", &CustomBinaryOperation{ID: "x", RequiredAuths: []*Authority{owner}}, "required_auths"},
+		{"valid custom binary", &CustomBinaryOperation{ID: "x", RequiredActiveAuths: []string{"alice"}}, ""},
+		{"zero weight beneficiary", &CommentOptionsOperation{Author: "bob", Permlink: "p", MaxAcceptedPayout: "1000000.000 SBD",
+			Extensions: beneficiaries(Beneficiary{Account: "alice", Weight: 0})}, ""},
+		{"beneficiary weight", &CommentOptionsOperation{Author: "bob", Permlink: "p", MaxAcceptedPayout: "1000000.000 SBD",
+			Extensions: beneficiaries(Beneficiary{Account: "alice", Weight: 10001})}, "extensions.beneficiaries.weight"},
+		{"no beneficiaries", &CommentOptionsOperation{Author: "bob", Permlink: "p", MaxAcceptedPayout: "1000000.000 SBD",
+			Extensions: beneficiaries()}, "extensions.beneficiaries"},
+		{"witness key", &WitnessSetPropertiesOperation{Owner: "alice", Props: StringBytesMap{}}, "props"},
+		{"valid witness props", &WitnessSetPropertiesOperation{Owner: "alice", Props: StringBytesMap{
+			"key":                    "02" + strings.Repeat("00", 32),
+			"account_creation_fee":   "b80b00000000000003535445454d0000",
+			"maximum_block_size":     "00000100",
+			"sbd_interest_rate":      "0000",
+			"sbd_exchange_rate":      "fa000000000000000353424400000000" + "e80300000000000003535445454d0000",
+			"url":                    "0968747470733a2f2f78",
+			"account_subsidy_budget": "19030000",
+			"account_subsidy_decay":  "b94c0500",
+			"unknown":                "ff",
+		}}, ""},
+		{"witness fee", &WitnessSetPropertiesOperation{Owner: "alice", Props: witnessProps("account_creation_fee", "000000000000000003535445454d0000")}, "props.account_creation_fee"},
+		{"witness fee symbol", &WitnessSetPropertiesOperation{Owner: "alice", Props: witnessProps("account_creation_fee", "b80b0000000000000353424400000000")}, "props.account_creation_fee"},
+		{"witness block size", &WitnessSetPropertiesOperation{Owner: "alice", Props: witnessProps("maximum_block_size", "00040000")}, "props.maximum_block_size"},
+		{"witness interest", &WitnessSetPropertiesOperation{Owner: "alice", Props: witnessProps("sbd_interest_rate", "1127")}, "props.sbd_interest_rate"},
+		{"witness price", &WitnessSetPropertiesOperation{Owner: "alice", Props: witnessProps("sbd_exchange_rate",
+			"e80300000000000003535445454d0000"+"fa000000000000000353424400000000")}, "props.sbd_exchange_rate"},
+		{"witness url", &WitnessSetPropertiesOperation{Owner: "alice", Props: witnessProps("url", "00")}, "props.url"},
+		{"witness budget", &WitnessSetPropertiesOperation{Owner: "alice", Props: witnessProps("account_subsidy_budget", "00000000")}, "props.account_subsidy_budget"},
+		{"witness decay", &WitnessSetPropertiesOperation{Owner: "alice", Props: witnessProps("account_subsidy_decay", "01000000")}, "props.account_subsidy_decay"},
+		{"witness new key", &WitnessSetPropertiesOperation{Owner: "alice", Props: witnessProps("new_signing_key", "05")}, "props.new_signing_key"},
+		{"witness hex", &WitnessSetPropertiesOperation{Owner: "alice", Props: witnessProps("url", "zz")}, "props.url"},
+	}
+	for _, tt := range tests {
+		err := tt.op.Validate()
+		if tt.field == "" {
+			if err != nil {
+				t.Errorf("%s: expected no error, got %v", tt.name, err)
+			}
+			continue
+		}
+		var fieldErr *FieldError
+		if !errors.As(err, &fieldErr) {
+			t.Errorf("%s: expected a FieldError, got %v", tt.name, err)
+			continue
+		}
+		if fieldErr.Field != tt.field {
+			t.Errorf("%s: expected field %v, got %v (%v)", tt.name, tt.field, fieldErr.Field, err)
+		}
+	}
+}
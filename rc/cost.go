@@ -0,0 +1,128 @@
+package rc
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/steemit/steemutil/consts"
+	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/protocol/api"
+	"github.com/steemit/steemutil/transaction"
+
+	"github.com/pkg/errors"
+)
+
+// Cost is the RC cost of a transaction, per resource and in total.
+type Cost struct {
+	Usage     Usage
+	Resources map[string]int64
+	Total     int64
+}
+
+// Estimator prices transactions using the parameters reported by rc_api.
+type Estimator struct {
+	Params   *api.RCResourceParams
+	Pool     *api.RCResourcePool
+	SizeInfo *SizeInfo
+
+	// RCRegen is the amount of RC the whole network regenerates per block.
+	RCRegen int64
+}
+
+// NewEstimator creates an Estimator from rc_api.get_resource_params,
+// rc_api.get_resource_pool and the dynamic global properties.
+func NewEstimator(params *api.RCResourceParams, pool *api.RCResourcePool, props *api.DynamicGlobalProperties) (*Estimator, error) {
+	if params == nil || pool == nil || props == nil {
+		return nil, errors.New("resource params, resource pool and global properties are required")
+	}
+
+	vests, err := protocol.ParseAsset(props.TotalVestingShares)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid total_vesting_shares")
+	}
+
+	return &Estimator{
+		Params:   params,
+		Pool:     pool,
+		SizeInfo: NewSizeInfo(params),
+		RCRegen:  vests.Amount / (consts.STEEM_RC_REGEN_TIME / consts.STEEM_BLOCK_INTERVAL),
+	}, nil
+}
+
+// Cost returns the RC cost of the given transaction.
+func (e *Estimator) Cost(tx *transaction.SignedTransaction) (*Cost, error) {
+	usage, err := CountResources(tx, e.SizeInfo)
+	if err != nil {
+		return nil, err
+	}
+	return e.UsageCost(usage)
+}
+
+// UsageCost prices the given resource usage.
+func (e *Estimator) UsageCost(usage Usage) (*Cost, error) {
+	cost := &Cost{
+		Usage:     make(Usage, len(usage)),
+		Resources: make(map[string]int64, len(usage)),
+	}
+
+	for _, name := range ResourceNames {
+		params, ok := e.Params.ResourceParams[name]
+		if !ok {
+			return nil, errors.Errorf("missing resource params for %s", name)
+		}
+
+		count := usage[name]
+		if unit := int64(params.ResourceDynamicsParams.ResourceUnit); unit > 0 {
+			count *= unit
+		}
+		cost.Usage[name] = count
+
+		pool := int64(e.Pool.ResourcePool[name].Pool)
+		c := ComputeCostOfResource(params.PriceCurveParams, pool, count, e.RCRegen)
+		cost.Resources[name] = c
+		cost.Total += c
+	}
+	return cost, nil
+}
+
+// ComputeCostOfResource mirrors compute_rc_cost_of_resource in the rc plugin:
+//
+//	cost = ((rc_regen * coeff_a) >> shift + 1) * count / (coeff_b + pool) + 1
+func ComputeCostOfResource(curve api.RCPriceCurveParams, pool, count, rcRegen int64) int64 {
+	if count == 0 {
+		return 0
+	}
+	if count < 0 {
+		return -ComputeCostOfResource(curve, pool, -count, rcRegen)
+	}
+
+	num := new(big.Int).Mul(big.NewInt(rcRegen), new(big.Int).SetUint64(uint64(curve.CoeffA)))
+	num.Rsh(num, uint(curve.Shift))
+	num.Add(num, big.NewInt(1))
+	num.Mul(num, big.NewInt(count))
+
+	denom := new(big.Int).SetUint64(uint64(curve.CoeffB))
+	if pool > 0 {
+		denom.Add(denom, big.NewInt(pool))
+	}
+
+	return num.Quo(num, denom).Int64() + 1
+}
+
+// CurrentMana returns the account's RC manabar regenerated up to now.
+func CurrentMana(account *api.RCAccount, now time.Time) protocol.Manabar {
+	return account.RCManabar.Regenerate(protocol.ManabarParams{
+		MaxMana:   int64(account.MaxRC),
+		RegenTime: consts.STEEM_RC_REGEN_TIME,
+	}, now)
+}
+
+// CanAfford reports whether the account has enough RC at the given time to
+// broadcast the transaction, together with the estimated cost.
+func (e *Estimator) CanAfford(account *api.RCAccount, tx *transaction.SignedTransaction, now time.Time) (bool, *Cost, error) {
+	cost, err := e.Cost(tx)
+	if err != nil {
+		return false, nil, err
+	}
+	return CurrentMana(account, now).HasMana(cost.Total), cost, nil
+}
@@ -0,0 +1,155 @@
+package rc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/protocol/api"
+)
+
+const testResourceParamsJSON = `{
+	"resource_names": ["resource_history_bytes", "resource_new_accounts", "resource_market_bytes", "resource_state_bytes", "resource_execution_time"],
+	"resource_params": {
+		"resource_history_bytes": {"resource_dynamics_params": {"resource_unit": 1, "budget_per_time_unit": 347222, "pool_eq": "216404314004", "max_pool_size": "432808628007", "decay_params": {"decay_per_time_unit": 3613026481, "decay_per_time_unit_denom_shift": 51}, "min_decay": 0}, "price_curve_params": {"coeff_a": "12981647055416481792", "coeff_b": "282927818", "shift": 49}},
+		"resource_new_accounts": {"resource_dynamics_params": {"resource_unit": 10000, "budget_per_time_unit": 797, "pool_eq": "157691079", "max_pool_size": "157691079", "decay_params": {"decay_per_time_unit": 347321, "decay_per_time_unit_denom_shift": 36}, "min_decay": 0}, "price_curve_params": {"coeff_a": "16484671763857882971", "coeff_b": "1300", "shift": 51}},
+		"resource_market_bytes": {"resource_dynamics_params": {"resource_unit": 10, "budget_per_time_unit": 578704, "pool_eq": "16030041350", "max_pool_size": "16030041350", "decay_params": {"decay_per_time_unit": 2540365427, "decay_per_time_unit_denom_shift": 46}, "min_decay": 0}, "price_curve_params": {"coeff_a": "18428548500000000000", "coeff_b": "6319058", "shift": 52}},
+		"resource_state_bytes": {"resource_dynamics_params": {"resource_unit": 1, "budget_per_time_unit": 231481481, "pool_eq": "144266395851369", "max_pool_size": "288532791702740", "decay_params": {"decay_per_time_unit": 3613026481, "decay_per_time_unit_denom_shift": 51}, "min_decay": 0}, "price_curve_params": {"coeff_a": "12981647055416481792", "coeff_b": "188619589483", "shift": 49}},
+		"resource_execution_time": {"resource_dynamics_params": {"resource_unit": 1, "budget_per_time_unit": 82191781, "pool_eq": "51225963024585", "max_pool_size": "102451926049170", "decay_params": {"decay_per_time_unit": 3613026481, "decay_per_time_unit_denom_shift": 51}, "min_decay": 0}, "price_curve_params": {"coeff_a": "12981647055416481792", "coeff_b": "66974022975", "shift": 49}}
+	},
+	"size_info": {
+		"resource_execution_time": {"vote_operation_exec_time": 26500}
+	}
+}`
+
+const testResourcePoolJSON = `{
+	"resource_pool": {
+		"resource_history_bytes": {"pool": "199290740471"},
+		"resource_new_accounts": {"pool": 37282},
+		"resource_market_bytes": {"pool": "15613542882"},
+		"resource_state_bytes": {"pool": "141734112958573"},
+		"resource_execution_time": {"pool": "50011627493491"}
+	}
+}`
+
+func newTestEstimator(t *testing.T) *Estimator {
+	var params api.RCResourceParams
+	if err := json.Unmarshal([]byte(testResourceParamsJSON), &params); err != nil {
+		t.Fatalf("failed to unmarshal resource params: %v", err)
+	}
+	var pool api.RCResourcePool
+	if err := json.Unmarshal([]byte(testResourcePoolJSON), &pool); err != nil {
+		t.Fatalf("failed to unmarshal resource pool: %v", err)
+	}
+	props := &api.DynamicGlobalProperties{TotalVestingShares: "288000000.000000 VESTS"}
+
+	e, err := NewEstimator(&params, &pool, props)
+	if err != nil {
+		t.Fatalf("NewEstimator failed: %v", err)
+	}
+	return e
+}
+
+func TestNewEstimator(t *testing.T) {
+	e := newTestEstimator(t)
+	if e.RCRegen != 2000000000 {
+		t.Errorf("expected rc regen 2000000000, got %d", e.RCRegen)
+	}
+	if uint64(e.Params.ResourceParams[ResourceHistoryBytes].PriceCurveParams.CoeffA) != 12981647055416481792 {
+		t.Errorf("unexpected coeff_a")
+	}
+}
+
+func TestComputeCostOfResource(t *testing.T) {
+	curve := api.RCPriceCurveParams{CoeffA: 1 << 10, CoeffB: 100, Shift: 10}
+
+	// ((1000 * 1024) >> 10 + 1) * 10 / (100 + 900) + 1 = 11
+	if got := ComputeCostOfResource(curve, 900, 10, 1000); got != 11 {
+		t.Errorf("expected 11, got %d", got)
+	}
+	if got := ComputeCostOfResource(curve, 900, 0, 1000); got != 0 {
+		t.Errorf("expected 0, got %d", got)
+	}
+	if got := ComputeCostOfResource(curve, 900, -10, 1000); got != -11 {
+		t.Errorf("expected -11, got %d", got)
+	}
+	// A negative pool is ignored.
+	if got := ComputeCostOfResource(curve, -900, 10, 1000); got != 101 {
+		t.Errorf("expected 101, got %d", got)
+	}
+}
+
+func TestEstimator_Cost(t *testing.T) {
+	e := newTestEstimator(t)
+	tx := newTestTransaction(&protocol.VoteOperation{
+		Voter:    "xeroc",
+		Author:   "xeroc",
+		Permlink: "piston",
+		Weight:   10000,
+	})
+
+	cost, err := e.Cost(tx)
+	if err != nil {
+		t.Fatalf("Cost failed: %v", err)
+	}
+
+	var sum int64
+	for _, name := range ResourceNames {
+		sum += cost.Resources[name]
+	}
+	if sum != cost.Total || cost.Total <= 0 {
+		t.Errorf("unexpected total %d (sum %d)", cost.Total, sum)
+	}
+	if cost.Resources[ResourceMarketBytes] != 0 || cost.Resources[ResourceNewAccounts] != 0 {
+		t.Errorf("unused resources must be free: %+v", cost.Resources)
+	}
+}
+
+func TestEstimator_CanAfford(t *testing.T) {
+	e := newTestEstimator(t)
+	tx := newTestTransaction(&protocol.VoteOperation{
+		Voter:    "xeroc",
+		Author:   "xeroc",
+		Permlink: "piston",
+		Weight:   10000,
+	})
+
+	now := time.Unix(1600000000, 0)
+	account := &api.RCAccount{
+		Account:   "xeroc",
+		RCManabar: protocol.Manabar{CurrentMana: 0, LastUpdateTime: protocol.UInt32(now.Unix())},
+		MaxRC:     1000000000000,
+	}
+
+	ok, cost, err := e.CanAfford(account, tx, now)
+	if err != nil {
+		t.Fatalf("CanAfford failed: %v", err)
+	}
+	if ok {
+		t.Error("expected an empty manabar to be unable to afford the transaction")
+	}
+
+	ok, _, err = e.CanAfford(account, tx, now.Add(5*24*time.Hour))
+	if err != nil {
+		t.Fatalf("CanAfford failed: %v", err)
+	}
+	if !ok {
+		t.Errorf("expected a full manabar to afford a cost of %d", cost.Total)
+	}
+}
+
+func TestRCAccounts_UnmarshalJSON(t *testing.T) {
+	data := `{"rc_accounts":[{"account":"steemit","rc_manabar":{"current_mana":"126893914813582","last_update_time":1600000000},"max_rc_creation_adjustment":{"amount":"2020748973","precision":6,"nai":"@@000000037"},"max_rc":"126893914813582"}]}`
+
+	var result api.RCAccounts
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if len(result.RCAccounts) != 1 || result.RCAccounts[0].MaxRC != 126893914813582 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if result.RCAccounts[0].RCManabar.CurrentMana != 126893914813582 {
+		t.Errorf("unexpected manabar: %+v", result.RCAccounts[0].RCManabar)
+	}
+}
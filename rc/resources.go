@@ -0,0 +1,309 @@
+package rc
+
+import (
+	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/protocol/api"
+	"github.com/steemit/steemutil/transaction"
+
+	"github.com/pkg/errors"
+)
+
+// Resource names as used by rc_api.
+const (
+	ResourceHistoryBytes  = "resource_history_bytes"
+	ResourceNewAccounts   = "resource_new_accounts"
+	ResourceMarketBytes   = "resource_market_bytes"
+	ResourceStateBytes    = "resource_state_bytes"
+	ResourceExecutionTime = "resource_execution_time"
+)
+
+// ResourceNames lists all resources in the order steemd reports them.
+var ResourceNames = []string{
+	ResourceHistoryBytes,
+	ResourceNewAccounts,
+	ResourceMarketBytes,
+	ResourceStateBytes,
+	ResourceExecutionTime,
+}
+
+// Scaling constants from the rc plugin's resource_sizes.hpp.
+// State bytes are weighted by how long the object is expected to live.
+const (
+	stateBytesScale                  = 10000
+	stateTransactionByteSize         = 174
+	stateTransferFromSavingsByteSize = 229
+	stateLimitOrderByteSize          = 1940
+	stateCommentVoteByteSize         = 525
+	defaultExecCost                  = 100000
+
+	// signatureSize is the packed size of one compact signature.
+	signatureSize = 65
+)
+
+// DefaultStateBytes mirrors state_object_size_info. Nodes report the live
+// values in the size_info of rc_api.get_resource_params.
+var DefaultStateBytes = map[string]int64{
+	"authority_base_size":                            4 * stateBytesScale,
+	"authority_account_member_size":                  18 * stateBytesScale,
+	"authority_key_member_size":                      35 * stateBytesScale,
+	"account_object_base_size":                       480 * stateBytesScale,
+	"account_authority_object_base_size":             40 * stateBytesScale,
+	"account_recovery_request_object_base_size":      32 * stateBytesScale,
+	"comment_object_base_size":                       201 * stateBytesScale,
+	"comment_object_permlink_char_size":              1 * stateBytesScale,
+	"comment_object_parent_permlink_char_size":       2 * stateBytesScale,
+	"comment_object_beneficiaries_member_size":       18 * stateBytesScale,
+	"comment_vote_object_base_size":                  47 * stateCommentVoteByteSize,
+	"convert_request_object_base_size":               48 * stateBytesScale,
+	"decline_voting_rights_request_object_base_size": 28 * stateBytesScale,
+	"escrow_object_base_size":                        119 * stateBytesScale,
+	"limit_order_object_base_size":                   76 * stateLimitOrderByteSize,
+	"savings_withdraw_object_byte_size":              64 * stateTransferFromSavingsByteSize,
+	"transaction_object_base_size":                   35 * stateTransactionByteSize,
+	"transaction_object_byte_size":                   stateTransactionByteSize,
+	"vesting_delegation_object_base_size":            60 * stateBytesScale,
+	"vesting_delegation_expiration_object_base_size": 44 * stateBytesScale,
+	"withdraw_vesting_route_object_base_size":        43 * stateBytesScale,
+	"witness_object_base_size":                       266 * stateBytesScale,
+	"witness_object_url_char_size":                   1 * stateBytesScale,
+	"witness_vote_object_base_size":                  40 * stateBytesScale,
+}
+
+// DefaultExecutionTime mirrors operation_exec_info, keyed by
+// "<operation>_operation_exec_time".
+var DefaultExecutionTime = map[string]int64{
+	"account_create_operation_exec_time":                 57700,
+	"account_create_with_delegation_operation_exec_time": 57700,
+	"account_update_operation_exec_time":                 14000,
+	"account_update2_operation_exec_time":                14000,
+	"account_witness_proxy_operation_exec_time":          117000,
+	"account_witness_vote_operation_exec_time":           23000,
+	"cancel_transfer_from_savings_operation_exec_time":   11500,
+	"change_recovery_account_operation_exec_time":        12000,
+	"claim_account_operation_exec_time":                  10000,
+	"claim_reward_balance_operation_exec_time":           50300,
+	"comment_operation_exec_time":                        114100,
+	"comment_options_operation_exec_time":                13200,
+	"convert_operation_exec_time":                        15700,
+	"create_claimed_account_operation_exec_time":         57700,
+	"custom_operation_exec_time":                         228000,
+	"custom_json_operation_exec_time":                    228000,
+	"custom_binary_operation_exec_time":                  228000,
+	"decline_voting_rights_operation_exec_time":          5300,
+	"delegate_vesting_shares_operation_exec_time":        19900,
+	"delete_comment_operation_exec_time":                 51100,
+	"escrow_approve_operation_exec_time":                 9900,
+	"escrow_dispute_operation_exec_time":                 11500,
+	"escrow_release_operation_exec_time":                 17200,
+	"escrow_transfer_operation_exec_time":                19500,
+	"feed_publish_operation_exec_time":                   6200,
+	"limit_order_cancel_operation_exec_time":             9600,
+	"limit_order_create_operation_exec_time":             31700,
+	"limit_order_create2_operation_exec_time":            31700,
+	"request_account_recovery_operation_exec_time":       54400,
+	"set_withdraw_vesting_route_operation_exec_time":     17900,
+	"transfer_from_savings_operation_exec_time":          17500,
+	"transfer_operation_exec_time":                       9600,
+	"transfer_to_savings_operation_exec_time":            6400,
+	"transfer_to_vesting_operation_exec_time":            44400,
+	"vote_operation_exec_time":                           26500,
+	"withdraw_vesting_operation_exec_time":               10400,
+	"witness_set_properties_operation_exec_time":         9500,
+	"witness_update_operation_exec_time":                 9500,
+}
+
+// SizeInfo holds the state byte sizes and execution times used to count resources.
+type SizeInfo struct {
+	StateBytes    map[string]int64
+	ExecutionTime map[string]int64
+}
+
+// DefaultSizeInfo returns a SizeInfo with the built-in defaults.
+func DefaultSizeInfo() *SizeInfo {
+	return &SizeInfo{
+		StateBytes:    DefaultStateBytes,
+		ExecutionTime: DefaultExecutionTime,
+	}
+}
+
+// NewSizeInfo returns the defaults overridden by the size_info reported by
+// rc_api.get_resource_params.
+func NewSizeInfo(params *api.RCResourceParams) *SizeInfo {
+	info := &SizeInfo{
+		StateBytes:    make(map[string]int64, len(DefaultStateBytes)),
+		ExecutionTime: make(map[string]int64, len(DefaultExecutionTime)),
+	}
+	for k, v := range DefaultStateBytes {
+		info.StateBytes[k] = v
+	}
+	for k, v := range DefaultExecutionTime {
+		info.ExecutionTime[k] = v
+	}
+	if params == nil {
+		return info
+	}
+	for k, v := range params.SizeInfo[ResourceStateBytes] {
+		info.StateBytes[k] = int64(v)
+	}
+	for k, v := range params.SizeInfo[ResourceExecutionTime] {
+		info.ExecutionTime[k] = int64(v)
+	}
+	return info
+}
+
+// Usage maps a resource name to the amount of the resource used.
+type Usage map[string]int64
+
+// CountResources computes the resources a transaction uses, following
+// count_resources in the rc plugin.
+//
+// The transaction size includes its signatures. An unsigned transaction is
+// counted as if it carried a single signature.
+func CountResources(tx *transaction.SignedTransaction, info *SizeInfo) (Usage, error) {
+	if info == nil {
+		info = DefaultSizeInfo()
+	}
+
+	size, err := packedSize(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	counter := &opCounter{info: info}
+	for _, op := range tx.Operations {
+		if err := counter.count(op); err != nil {
+			return nil, err
+		}
+	}
+
+	usage := Usage{
+		ResourceHistoryBytes:  size,
+		ResourceNewAccounts:   counter.newAccounts,
+		ResourceMarketBytes:   0,
+		ResourceStateBytes:    info.StateBytes["transaction_object_base_size"] + info.StateBytes["transaction_object_byte_size"]*size + counter.stateBytes,
+		ResourceExecutionTime: counter.executionTime,
+	}
+	if counter.marketOps > 0 {
+		usage[ResourceMarketBytes] = size
+	}
+	return usage, nil
+}
+
+// packedSize returns the size of the binary-serialized signed transaction.
+func packedSize(tx *transaction.SignedTransaction) (int64, error) {
+	raw, err := tx.Serialize()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to serialize transaction")
+	}
+
+	sigs := len(tx.Signatures)
+	if sigs == 0 {
+		sigs = 1
+	}
+	return int64(len(raw)) + uvarintSize(uint64(sigs)) + int64(sigs*signatureSize), nil
+}
+
+func uvarintSize(v uint64) int64 {
+	n := int64(1)
+	for v >= 0x80 {
+		v >>= 7
+		n++
+	}
+	return n
+}
+
+type opCounter struct {
+	info          *SizeInfo
+	stateBytes    int64
+	executionTime int64
+	newAccounts   int64
+	marketOps     int64
+}
+
+func (c *opCounter) exec(opType protocol.OpType) {
+	if v, ok := c.info.ExecutionTime[string(opType)+"_operation_exec_time"]; ok {
+		c.executionTime += v
+		return
+	}
+	c.executionTime += defaultExecCost
+}
+
+func (c *opCounter) authority(auth *protocol.Authority) int64 {
+	if auth == nil {
+		return 0
+	}
+	return c.info.StateBytes["authority_base_size"] +
+		c.info.StateBytes["authority_account_member_size"]*int64(len(auth.AccountAuths)) +
+		c.info.StateBytes["authority_key_member_size"]*int64(len(auth.KeyAuths))
+}
+
+func (c *opCounter) account(owner, active, posting *protocol.Authority) int64 {
+	return c.info.StateBytes["account_object_base_size"] +
+		c.info.StateBytes["account_authority_object_base_size"] +
+		c.authority(owner) + c.authority(active) + c.authority(posting)
+}
+
+func (c *opCounter) count(op protocol.Operation) error {
+	c.exec(op.Type())
+	w := c.info.StateBytes
+
+	switch op := op.(type) {
+	case *protocol.AccountCreateOperation:
+		c.stateBytes += c.account(op.Owner, op.Active, op.Posting)
+	case *protocol.AccountCreateWithDelegationOperation:
+		c.stateBytes += c.account(op.Owner, op.Active, op.Posting) + w["vesting_delegation_object_base_size"]
+	case *protocol.CreateClaimedAccountOperation:
+		c.stateBytes += c.account(op.Owner, op.Active, op.Posting)
+	case *protocol.ClaimAccountOperation:
+		fee, err := protocol.ParseAsset(op.Fee)
+		if err != nil {
+			return errors.Wrapf(err, "invalid claim_account fee: %s", op.Fee)
+		}
+		if fee.Amount == 0 {
+			c.newAccounts++
+		}
+	case *protocol.AccountWitnessVoteOperation:
+		if op.Approve {
+			c.stateBytes += w["witness_vote_object_base_size"]
+		}
+	case *protocol.CommentOperation:
+		c.stateBytes += w["comment_object_base_size"] +
+			w["comment_object_permlink_char_size"]*int64(len(op.Permlink)) +
+			w["comment_object_parent_permlink_char_size"]*int64(len(op.ParentPermlink))
+	case *protocol.VoteOperation:
+		c.stateBytes += w["comment_vote_object_base_size"]
+	case *protocol.ConvertOperation:
+		c.stateBytes += w["convert_request_object_base_size"]
+		c.marketOps++
+	case *protocol.DeclineVotingRightsOperation:
+		if op.Decline {
+			c.stateBytes += w["decline_voting_rights_request_object_base_size"]
+		}
+	case *protocol.DelegateVestingSharesOperation:
+		c.stateBytes += w["vesting_delegation_object_base_size"]
+	case *protocol.EscrowTransferOperation:
+		c.stateBytes += w["escrow_object_base_size"]
+		c.marketOps++
+	case *protocol.LimitOrderCreateOperation:
+		if !op.FillOrKill {
+			c.stateBytes += w["limit_order_object_base_size"]
+		}
+		c.marketOps++
+	case *protocol.LimitOrderCreate2Operation:
+		if !op.FillOrKill {
+			c.stateBytes += w["limit_order_object_base_size"]
+		}
+		c.marketOps++
+	case *protocol.RequestAccountRecoveryOperation:
+		c.stateBytes += w["account_recovery_request_object_base_size"]
+	case *protocol.SetWithdrawVestingRouteOperation:
+		c.stateBytes += w["withdraw_vesting_route_object_base_size"]
+	case *protocol.TransferFromSavingsOperation:
+		c.stateBytes += w["savings_withdraw_object_byte_size"]
+	case *protocol.WitnessUpdateOperation:
+		c.stateBytes += w["witness_object_base_size"] + w["witness_object_url_char_size"]*int64(len(op.URL))
+	case *protocol.TransferOperation, *protocol.TransferToVestingOperation, *protocol.TransferToSavingsOperation:
+		c.marketOps++
+	}
+	return nil
+}
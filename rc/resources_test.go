@@ -0,0 +1,106 @@
+package rc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/protocol/api"
+	"github.com/steemit/steemutil/transaction"
+)
+
+func newTestTransaction(ops ...protocol.Operation) *transaction.SignedTransaction {
+	expiration := time.Date(2016, 8, 8, 12, 24, 17, 0, time.UTC)
+	tx := &transaction.Transaction{
+		RefBlockNum:    36029,
+		RefBlockPrefix: 1164960351,
+		Expiration:     &protocol.Time{Time: &expiration},
+	}
+	for _, op := range ops {
+		tx.PushOperation(op)
+	}
+	return transaction.NewSignedTransaction(tx)
+}
+
+func TestCountResources_Vote(t *testing.T) {
+	tx := newTestTransaction(&protocol.VoteOperation{
+		Voter:    "xeroc",
+		Author:   "xeroc",
+		Permlink: "piston",
+		Weight:   10000,
+	})
+
+	usage, err := CountResources(tx, nil)
+	if err != nil {
+		t.Fatalf("CountResources failed: %v", err)
+	}
+
+	// 34 bytes of transaction + 1 byte signature count + 65 bytes signature.
+	if usage[ResourceHistoryBytes] != 100 {
+		t.Errorf("expected 100 history bytes, got %d", usage[ResourceHistoryBytes])
+	}
+	if usage[ResourceMarketBytes] != 0 {
+		t.Errorf("expected no market bytes, got %d", usage[ResourceMarketBytes])
+	}
+	expectedState := int64(35*174 + 174*100 + 47*525)
+	if usage[ResourceStateBytes] != expectedState {
+		t.Errorf("expected %d state bytes, got %d", expectedState, usage[ResourceStateBytes])
+	}
+	if usage[ResourceExecutionTime] != 26500 {
+		t.Errorf("expected execution time 26500, got %d", usage[ResourceExecutionTime])
+	}
+}
+
+func TestCountResources_Market(t *testing.T) {
+	tx := newTestTransaction(&protocol.TransferOperation{
+		From:   "alice",
+		To:     "bob",
+		Amount: "1.000 STEEM",
+		Memo:   "",
+	})
+
+	usage, err := CountResources(tx, nil)
+	if err != nil {
+		t.Fatalf("CountResources failed: %v", err)
+	}
+	if usage[ResourceMarketBytes] != usage[ResourceHistoryBytes] {
+		t.Errorf("expected market bytes %d, got %d", usage[ResourceHistoryBytes], usage[ResourceMarketBytes])
+	}
+}
+
+func TestCountResources_ClaimAccount(t *testing.T) {
+	tx := newTestTransaction(
+		&protocol.ClaimAccountOperation{Creator: "alice", Fee: "0.000 STEEM", Extensions: []any{}},
+		&protocol.ClaimAccountOperation{Creator: "alice", Fee: "3.000 STEEM", Extensions: []any{}},
+	)
+
+	usage, err := CountResources(tx, nil)
+	if err != nil {
+		t.Fatalf("CountResources failed: %v", err)
+	}
+	if usage[ResourceNewAccounts] != 1 {
+		t.Errorf("expected 1 new account, got %d", usage[ResourceNewAccounts])
+	}
+	if usage[ResourceExecutionTime] != 20000 {
+		t.Errorf("expected execution time 20000, got %d", usage[ResourceExecutionTime])
+	}
+}
+
+func TestNewSizeInfo(t *testing.T) {
+	params := &api.RCResourceParams{
+		SizeInfo: map[string]map[string]protocol.Int64{
+			ResourceExecutionTime: {"vote_operation_exec_time": 1},
+		},
+	}
+
+	info := NewSizeInfo(params)
+	if info.ExecutionTime["vote_operation_exec_time"] != 1 {
+		t.Errorf("expected override to be applied")
+	}
+	if info.ExecutionTime["comment_operation_exec_time"] != DefaultExecutionTime["comment_operation_exec_time"] {
+		t.Errorf("expected defaults to be kept")
+	}
+	if DefaultExecutionTime["vote_operation_exec_time"] != 26500 {
+		t.Errorf("defaults must not be modified")
+	}
+}
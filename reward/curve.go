@@ -0,0 +1,63 @@
+package reward
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// Reward curves as reported by get_reward_fund.
+const (
+	CurveQuadratic            = "quadratic"
+	CurveLinear               = "linear"
+	CurveSquareRoot           = "square_root"
+	CurveConvergentLinear     = "convergent_linear"
+	CurveConvergentSquareRoot = "convergent_square_root"
+)
+
+// EvaluateRewardCurve converts rshares into reward claims the way
+// util::evaluate_reward_curve does in steemd.
+// Non-positive rshares never earn claims, so zero is returned for them.
+//
+// Square roots are computed exactly while steemd uses approx_sqrt, so the
+// square root curves can differ from the chain in the least significant bits.
+func EvaluateRewardCurve(rshares *big.Int, curve string, contentConstant *big.Int) (*big.Int, error) {
+	if rshares.Sign() <= 0 {
+		return new(big.Int), nil
+	}
+
+	r := new(big.Int).Set(rshares)
+	s := contentConstant
+	switch curve {
+	case CurveQuadratic:
+		// (r + s)^2 - s^2
+		rs := new(big.Int).Add(r, s)
+		rs.Mul(rs, rs)
+		return rs.Sub(rs, new(big.Int).Mul(s, s)), nil
+
+	case CurveLinear:
+		return r, nil
+
+	case CurveSquareRoot:
+		return r.Sqrt(r), nil
+
+	case CurveConvergentLinear:
+		// ((r + s)^2 - s^2) / (r + 4s)
+		rs := new(big.Int).Add(r, s)
+		rs.Mul(rs, rs)
+		rs.Sub(rs, new(big.Int).Mul(s, s))
+		denom := new(big.Int).Mul(s, big.NewInt(4))
+		denom.Add(denom, r)
+		return rs.Quo(rs, denom), nil
+
+	case CurveConvergentSquareRoot:
+		// r / sqrt(r + 2s)
+		denom := new(big.Int).Mul(s, big.NewInt(2))
+		denom.Add(denom, r)
+		denom.Sqrt(denom)
+		return r.Quo(r, denom), nil
+
+	default:
+		return nil, errors.Errorf("unknown reward curve: %s", curve)
+	}
+}
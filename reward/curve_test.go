@@ -0,0 +1,45 @@
+package reward
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestEvaluateRewardCurve(t *testing.T) {
+	s := big.NewInt(2000000000000)
+
+	tests := []struct {
+		name     string
+		curve    string
+		rshares  int64
+		expected string
+	}{
+		{"linear", CurveLinear, 1000000000, "1000000000"},
+		{"negative rshares", CurveLinear, -1000000000, "0"},
+		{"square root", CurveSquareRoot, 1000000, "1000"},
+		// ((1e12 + 2e12)^2 - (2e12)^2) / (1e12 + 8e12) = 5e24 / 9e12
+		{"convergent linear", CurveConvergentLinear, 1000000000000, "555555555555"},
+		// (1e12 + 2e12)^2 - (2e12)^2
+		{"quadratic", CurveQuadratic, 1000000000000, "5000000000000000000000000"},
+		// 4e12 / sqrt(4e12 + 4e12)
+		{"convergent square root", CurveConvergentSquareRoot, 4000000000000, "1414213"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvaluateRewardCurve(big.NewInt(tt.rshares), tt.curve, s)
+			if err != nil {
+				t.Fatalf("EvaluateRewardCurve failed: %v", err)
+			}
+			if got.String() != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got.String())
+			}
+		})
+	}
+}
+
+func TestEvaluateRewardCurve_Unknown(t *testing.T) {
+	if _, err := EvaluateRewardCurve(big.NewInt(1), "bounded_curation", big.NewInt(1)); err == nil {
+		t.Error("expected error for unknown curve")
+	}
+}
@@ -0,0 +1,215 @@
+package reward
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/consts"
+	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/protocol/api"
+)
+
+// Payout is a post payout split between the author and the curators.
+type Payout struct {
+	Total    *protocol.Asset
+	Author   *protocol.Asset
+	Curation *protocol.Asset
+}
+
+// Estimator estimates vote values and post payouts from a reward fund
+// (get_reward_fund) and the median price (get_current_median_history_price).
+type Estimator struct {
+	RewardBalance   int64
+	RecentClaims    *big.Int
+	ContentConstant *big.Int
+	AuthorCurve     string
+	CurationPercent int64
+
+	// The median price expressed as raw SBD per raw STEEM.
+	PriceSBD   int64
+	PriceSteem int64
+}
+
+// NewEstimator creates an Estimator from the given reward fund and median price.
+func NewEstimator(fund *api.RewardFund, medianPrice *api.Price) (*Estimator, error) {
+	if fund == nil || medianPrice == nil {
+		return nil, errors.New("reward fund and median price are required")
+	}
+
+	balance, err := fund.RewardBalance.Parse()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid reward_balance")
+	}
+	if balance.Symbol != "STEEM" {
+		return nil, errors.Errorf("invalid reward_balance symbol: %s", balance.Symbol)
+	}
+
+	recentClaims, ok := new(big.Int).SetString(string(fund.RecentClaims), 10)
+	if !ok || recentClaims.Sign() <= 0 {
+		return nil, errors.Errorf("invalid recent_claims: %s", fund.RecentClaims)
+	}
+
+	contentConstant := big.NewInt(consts.STEEM_CONTENT_CONSTANT_HF21)
+	if fund.ContentConstant != "" {
+		if _, ok := contentConstant.SetString(string(fund.ContentConstant), 10); !ok {
+			return nil, errors.Errorf("invalid content_constant: %s", fund.ContentConstant)
+		}
+	}
+
+	authorCurve := fund.AuthorRewardCurve
+	if authorCurve == "" {
+		authorCurve = CurveConvergentLinear
+	}
+
+	sbd, steem, err := parsePrice(medianPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Estimator{
+		RewardBalance:   balance.Amount,
+		RecentClaims:    recentClaims,
+		ContentConstant: contentConstant,
+		AuthorCurve:     authorCurve,
+		CurationPercent: int64(fund.PercentCurationRewards),
+		PriceSBD:        sbd,
+		PriceSteem:      steem,
+	}, nil
+}
+
+// parsePrice returns the SBD and STEEM sides of a price, in either orientation.
+func parsePrice(price *api.Price) (int64, int64, error) {
+	base, err := price.Base.Parse()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "invalid price base")
+	}
+	quote, err := price.Quote.Parse()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "invalid price quote")
+	}
+
+	switch {
+	case base.Symbol == "SBD" && quote.Symbol == "STEEM":
+		if quote.Amount <= 0 {
+			return 0, 0, errors.New("invalid price: zero STEEM amount")
+		}
+		return base.Amount, quote.Amount, nil
+	case base.Symbol == "STEEM" && quote.Symbol == "SBD":
+		if base.Amount <= 0 {
+			return 0, 0, errors.New("invalid price: zero STEEM amount")
+		}
+		return quote.Amount, base.Amount, nil
+	default:
+		return 0, 0, errors.Errorf("invalid price: %s / %s", price.Base, price.Quote)
+	}
+}
+
+// Claims returns the reward claims the given rshares earn on the author curve.
+func (e *Estimator) Claims(rshares int64) *big.Int {
+	claims, err := EvaluateRewardCurve(big.NewInt(rshares), e.AuthorCurve, e.ContentConstant)
+	if err != nil {
+		return new(big.Int)
+	}
+	return claims
+}
+
+// ClaimsToSteem converts reward claims into raw STEEM from the reward fund.
+func (e *Estimator) ClaimsToSteem(claims *big.Int) int64 {
+	steem := new(big.Int).Mul(claims, big.NewInt(e.RewardBalance))
+	steem.Quo(steem, e.RecentClaims)
+	return steem.Int64()
+}
+
+// ToSBD converts raw STEEM into raw SBD at the median price.
+func (e *Estimator) ToSBD(steem int64) int64 {
+	sbd := new(big.Int).Mul(big.NewInt(steem), big.NewInt(e.PriceSBD))
+	return sbd.Quo(sbd, big.NewInt(e.PriceSteem)).Int64()
+}
+
+// ToSteem converts raw SBD into raw STEEM at the median price.
+func (e *Estimator) ToSteem(sbd int64) int64 {
+	if e.PriceSBD == 0 {
+		return 0
+	}
+	steem := new(big.Int).Mul(big.NewInt(sbd), big.NewInt(e.PriceSteem))
+	return steem.Quo(steem, big.NewInt(e.PriceSBD)).Int64()
+}
+
+// VoteValue returns the SBD value a vote adds to a post.
+//
+// Because the author curve is not linear, the value depends on the rshares
+// the post already has; pass zero for a post without votes.
+func (e *Estimator) VoteValue(voteRShares, postRShares int64) *protocol.Asset {
+	before := e.Claims(postRShares)
+	after := e.Claims(postRShares + voteRShares)
+	claims := after.Sub(after, before)
+
+	var steem int64
+	if claims.Sign() >= 0 {
+		steem = e.ClaimsToSteem(claims)
+	} else {
+		steem = -e.ClaimsToSteem(claims.Neg(claims))
+	}
+	return sbdAsset(e.ToSBD(steem))
+}
+
+// VoteValueFor returns the SBD value of a vote with the given weight cast by
+// an account with the given mana at the given time.
+func (e *Estimator) VoteValueFor(mana *protocol.VotingMana, weight protocol.Int16, postRShares int64, now time.Time) *protocol.Asset {
+	return e.VoteValue(mana.RShares(weight, now), postRShares)
+}
+
+// PendingPayout estimates the payout of a post with the given net_rshares.
+//
+// Like steemd, payouts below STEEM_MIN_PAYOUT_SBD are treated as dust and
+// paid as zero, and payouts are capped at maxAcceptedPayout when it is set.
+func (e *Estimator) PendingPayout(netRShares int64, maxAcceptedPayout string) (*Payout, error) {
+	steem := e.ClaimsToSteem(e.Claims(netRShares))
+
+	if e.ToSBD(steem) < consts.STEEM_MIN_PAYOUT_SBD {
+		steem = 0
+	}
+
+	if maxAcceptedPayout != "" {
+		maxPayout, err := protocol.ParseAsset(maxAcceptedPayout)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid max_accepted_payout")
+		}
+		if maxSteem := e.ToSteem(maxPayout.Amount); steem > maxSteem {
+			steem = maxSteem
+		}
+	}
+
+	author, curation := SplitPayout(steem, e.CurationPercent)
+	return &Payout{
+		Total:    sbdAsset(e.ToSBD(steem)),
+		Author:   sbdAsset(e.ToSBD(author)),
+		Curation: sbdAsset(e.ToSBD(curation)),
+	}, nil
+}
+
+// VoteRShares returns the rshares the given vote operation would produce.
+func VoteRShares(mana *protocol.VotingMana, op *protocol.VoteOperation, now time.Time) int64 {
+	return mana.RShares(op.Weight, now)
+}
+
+// SplitPayout splits a payout into the author and curation parts.
+//
+// curationPercent is the reward fund's percent_curation_rewards in basis points.
+// Note that DynamicGlobalProperties.ContentRewardPercent is the share of
+// inflation flowing into the reward fund and does not affect this split.
+func SplitPayout(total, curationPercent int64) (author, curation int64) {
+	c := new(big.Int).Mul(big.NewInt(total), big.NewInt(curationPercent))
+	c.Quo(c, big.NewInt(consts.STEEM_100_PERCENT))
+	curation = c.Int64()
+	return total - curation, curation
+}
+
+func sbdAsset(amount int64) *protocol.Asset {
+	return &protocol.Asset{
+		Amount:    amount,
+		Precision: 3,
+		Symbol:    "SBD",
+	}
+}
@@ -0,0 +1,142 @@
+package reward
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/protocol/api"
+)
+
+const testRewardFundJSON = `{
+	"id": 0,
+	"name": "post",
+	"reward_balance": "800000.000 STEEM",
+	"recent_claims": "400000000000000000",
+	"last_update": "2020-09-13T12:26:40",
+	"content_constant": "2000000000000",
+	"percent_curation_rewards": 5000,
+	"percent_content_rewards": 10000,
+	"author_reward_curve": "linear",
+	"curation_reward_curve": "linear"
+}`
+
+func newTestEstimator(t *testing.T) *Estimator {
+	var fund api.RewardFund
+	if err := json.Unmarshal([]byte(testRewardFundJSON), &fund); err != nil {
+		t.Fatalf("failed to unmarshal reward fund: %v", err)
+	}
+	price := &api.Price{Base: "0.250 SBD", Quote: "1.000 STEEM"}
+
+	e, err := NewEstimator(&fund, price)
+	if err != nil {
+		t.Fatalf("NewEstimator failed: %v", err)
+	}
+	return e
+}
+
+func TestNewEstimator(t *testing.T) {
+	e := newTestEstimator(t)
+	if e.RewardBalance != 800000000 {
+		t.Errorf("expected reward balance 800000000, got %d", e.RewardBalance)
+	}
+	if e.CurationPercent != 5000 {
+		t.Errorf("expected curation percent 5000, got %d", e.CurationPercent)
+	}
+	if e.PriceSBD != 250 || e.PriceSteem != 1000 {
+		t.Errorf("unexpected price %d/%d", e.PriceSBD, e.PriceSteem)
+	}
+}
+
+func TestNewEstimator_InvertedPrice(t *testing.T) {
+	fund := &api.RewardFund{RewardBalance: "1.000 STEEM", RecentClaims: "1"}
+	e, err := NewEstimator(fund, &api.Price{Base: "4.000 STEEM", Quote: "1.000 SBD"})
+	if err != nil {
+		t.Fatalf("NewEstimator failed: %v", err)
+	}
+	if got := e.ToSBD(4000); got != 1000 {
+		t.Errorf("expected 1000, got %d", got)
+	}
+}
+
+func TestNewEstimator_Invalid(t *testing.T) {
+	price := &api.Price{Base: "0.250 SBD", Quote: "1.000 STEEM"}
+	if _, err := NewEstimator(&api.RewardFund{RewardBalance: "1.000 STEEM", RecentClaims: "0"}, price); err == nil {
+		t.Error("expected error for zero recent_claims")
+	}
+	if _, err := NewEstimator(&api.RewardFund{RewardBalance: "1.000 SBD", RecentClaims: "1"}, price); err == nil {
+		t.Error("expected error for non-STEEM reward balance")
+	}
+}
+
+func TestEstimator_VoteValue(t *testing.T) {
+	e := newTestEstimator(t)
+
+	// 1e12 rshares = 1e12 / 4e17 of 800000.000 STEEM = 2.000 STEEM = 0.500 SBD
+	got := e.VoteValue(1000000000000, 0)
+	if got.String() != "0.500 SBD" {
+		t.Errorf("expected 0.500 SBD, got %s", got)
+	}
+}
+
+func TestEstimator_VoteValueFor(t *testing.T) {
+	e := newTestEstimator(t)
+	now := time.Unix(1600000000, 0)
+	manabar := protocol.Manabar{CurrentMana: 50000000000000, LastUpdateTime: protocol.UInt32(now.Unix())}
+
+	mana, err := protocol.NewVotingMana(manabar, manabar, "50000000.000000 VESTS", "", "", "", 0, 0)
+	if err != nil {
+		t.Fatalf("NewVotingMana failed: %v", err)
+	}
+
+	op := &protocol.VoteOperation{Voter: "alice", Author: "bob", Permlink: "post", Weight: 10000}
+	rshares := VoteRShares(mana, op, now)
+	if rshares != 1000000000000-50000000 {
+		t.Errorf("unexpected rshares %d", rshares)
+	}
+
+	got := e.VoteValueFor(mana, op.Weight, 0, now)
+	if got.String() != "0.499 SBD" {
+		t.Errorf("expected 0.499 SBD, got %s", got)
+	}
+}
+
+func TestEstimator_PendingPayout(t *testing.T) {
+	e := newTestEstimator(t)
+
+	payout, err := e.PendingPayout(40000000000000, "")
+	if err != nil {
+		t.Fatalf("PendingPayout failed: %v", err)
+	}
+	// 4e13 / 4e17 * 800000.000 STEEM = 80.000 STEEM = 20.000 SBD
+	if payout.Total.String() != "20.000 SBD" {
+		t.Errorf("expected total 20.000 SBD, got %s", payout.Total)
+	}
+	if payout.Author.String() != "10.000 SBD" || payout.Curation.String() != "10.000 SBD" {
+		t.Errorf("unexpected split %s / %s", payout.Author, payout.Curation)
+	}
+
+	capped, err := e.PendingPayout(40000000000000, "5.000 SBD")
+	if err != nil {
+		t.Fatalf("PendingPayout failed: %v", err)
+	}
+	if capped.Total.String() != "5.000 SBD" {
+		t.Errorf("expected capped total 5.000 SBD, got %s", capped.Total)
+	}
+
+	dust, err := e.PendingPayout(10000000000, "")
+	if err != nil {
+		t.Fatalf("PendingPayout failed: %v", err)
+	}
+	if dust.Total.Amount != 0 {
+		t.Errorf("expected dust payout to be zero, got %s", dust.Total)
+	}
+}
+
+func TestSplitPayout(t *testing.T) {
+	author, curation := SplitPayout(1001, 2500)
+	if author != 751 || curation != 250 {
+		t.Errorf("expected 751/250, got %d/%d", author, curation)
+	}
+}
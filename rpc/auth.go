@@ -9,7 +9,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
-	"github.com/steemit/steemutil/wif"
+	"github.com/steemit/steemutil/signer"
 )
 
 // K is the signing constant used to reserve opcode space and prevent cross-protocol attacks.
@@ -23,9 +23,12 @@ var K = []byte{
 
 // RpcRequest represents a JSON-RPC request to be signed.
 type RpcRequest struct {
-	Method string        `json:"method"`
-	Params []interface{} `json:"params"`
-	ID     int           `json:"id"`
+	Method string `json:"method"`
+
+	// Params is a positional []interface{}, an object or a json.RawMessage.
+	// Its JSON encoding is what gets signed.
+	Params interface{} `json:"params"`
+	ID     int         `json:"id"`
 }
 
 // SignedRequest represents a signed JSON-RPC request.
@@ -50,6 +53,19 @@ type SignedParams struct {
 // Sign creates a signed JSON-RPC request.
 // The request is signed using the provided account and private keys.
 func Sign(request *RpcRequest, account string, privateKeys []string) (*SignedRequest, error) {
+	signers := make([]signer.Signer, 0, len(privateKeys))
+	for _, keyWif := range privateKeys {
+		s, err := signer.FromWif(keyWif)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode private key: %s", keyWif)
+		}
+		signers = append(signers, s)
+	}
+	return SignWith(request, account, signers)
+}
+
+// SignWith creates a signed JSON-RPC request using the given signers.
+func SignWith(request *RpcRequest, account string, signers []signer.Signer) (*SignedRequest, error) {
 	if request.Params == nil {
 		return nil, errors.New("unable to sign a request without params")
 	}
@@ -74,15 +90,10 @@ func Sign(request *RpcRequest, account string, privateKeys []string) (*SignedReq
 	// Create message hash
 	message := hashMessage(timestamp, account, request.Method, params, nonceBytes)
 
-	// Sign with each private key
-	signatures := make([]string, 0, len(privateKeys))
-	for _, keyWif := range privateKeys {
-		privateKey := &wif.PrivateKey{}
-		if err := privateKey.FromWif(keyWif); err != nil {
-			return nil, errors.Wrapf(err, "failed to decode private key: %s", keyWif)
-		}
-
-		signature, err := privateKey.SignSha256(message)
+	// Sign with each signer
+	signatures := make([]string, 0, len(signers))
+	for _, s := range signers {
+		signature, err := s.SignDigest(message)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to sign message")
 		}
@@ -107,9 +118,72 @@ func Sign(request *RpcRequest, account string, privateKeys []string) (*SignedReq
 	return signedRequest, nil
 }
 
+// Default windows used by Validate.
+const (
+	DefaultMaxAge  = 60 * time.Second
+	DefaultMaxSkew = 60 * time.Second
+)
+
+// ValidateOption configures Validate.
+type ValidateOption func(*validateOptions)
+
+type validateOptions struct {
+	maxAge  time.Duration
+	maxSkew time.Duration
+	nonces  NonceStore
+	now     func() time.Time
+}
+
+// WithMaxAge sets how long after its timestamp a request is accepted.
+func WithMaxAge(d time.Duration) ValidateOption {
+	return func(o *validateOptions) { o.maxAge = d }
+}
+
+// WithMaxSkew sets how far in the future a request timestamp may be, to
+// tolerate clients whose clock runs ahead.
+func WithMaxSkew(d time.Duration) ValidateOption {
+	return func(o *validateOptions) { o.maxSkew = d }
+}
+
+// WithNonceStore rejects requests whose account and nonce were already
+// validated. Nonces are kept until the request would expire anyway.
+func WithNonceStore(store NonceStore) ValidateOption {
+	return func(o *validateOptions) { o.nonces = store }
+}
+
+// WithClock sets the clock used to check timestamps.
+func WithClock(now func() time.Time) ValidateOption {
+	return func(o *validateOptions) { o.now = now }
+}
+
 // Validate validates a signed JSON-RPC request.
 // The verifyFunc should verify that the signatures are valid for the given account.
-func Validate(request *SignedRequest, verifyFunc func(message []byte, signatures []string, account string) error) ([]interface{}, error) {
+// Without options, requests are accepted from DefaultMaxSkew before to
+// DefaultMaxAge after their timestamp and nonces are not remembered.
+func Validate(request *SignedRequest, verifyFunc VerifyFunc, opts ...ValidateOption) ([]interface{}, error) {
+	paramsJSON, err := ValidateRaw(request, verifyFunc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var params []interface{}
+	if err := json.Unmarshal(paramsJSON, &params); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal params")
+	}
+	return params, nil
+}
+
+// ValidateRaw is Validate returning the signed params as JSON, for requests
+// whose params are an object rather than positional.
+func ValidateRaw(request *SignedRequest, verifyFunc VerifyFunc, opts ...ValidateOption) (json.RawMessage, error) {
+	options := &validateOptions{
+		maxAge:  DefaultMaxAge,
+		maxSkew: DefaultMaxSkew,
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	if request.JsonRpc != "2.0" || request.Method == "" {
 		return nil, errors.New("invalid JSON RPC request")
 	}
@@ -121,14 +195,12 @@ func Validate(request *SignedRequest, verifyFunc func(message []byte, signatures
 	}
 
 	// Decode and validate params
-	var params []interface{}
 	paramsJSON, err := base64.StdEncoding.DecodeString(signed.Params)
 	if err != nil {
 		return nil, errors.Wrap(err, "invalid encoded params")
 	}
-
-	if err := json.Unmarshal(paramsJSON, &params); err != nil {
-		return nil, errors.Wrap(err, "failed to unmarshal params")
+	if !json.Valid(paramsJSON) {
+		return nil, errors.New("failed to unmarshal params: invalid JSON")
 	}
 
 	// Validate nonce
@@ -147,10 +219,14 @@ func Validate(request *SignedRequest, verifyFunc func(message []byte, signatures
 		return nil, errors.Wrap(err, "invalid timestamp")
 	}
 
-	// Check if signature has expired (60 seconds)
-	if time.Since(timestamp) > 60*time.Second {
+	// Check the signature is neither expired nor too far in the future
+	age := options.now().Sub(timestamp)
+	if age > options.maxAge {
 		return nil, errors.New("signature expired")
 	}
+	if -age > options.maxSkew {
+		return nil, errors.New("signature timestamp is in the future")
+	}
 
 	// Recreate message hash
 	message := hashMessage(signed.Timestamp, signed.Account, request.Method, signed.Params, nonceBytes)
@@ -160,7 +236,20 @@ func Validate(request *SignedRequest, verifyFunc func(message []byte, signatures
 		return nil, errors.Wrap(err, "verification failed")
 	}
 
-	return params, nil
+	// Reject replays, once the request is known to be authentic
+	if options.nonces != nil {
+		// Key on the decoded nonce, which is what is signed, so that
+		// changing the case of the hex digits does not make a new nonce.
+		added, err := options.nonces.Add(signed.Account+"/"+hex.EncodeToString(nonceBytes), timestamp.Add(options.maxAge))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to store nonce")
+		}
+		if !added {
+			return nil, ErrNonceReused
+		}
+	}
+
+	return paramsJSON, nil
 }
 
 // hashMessage creates the message hash to be signed.
@@ -184,21 +273,14 @@ func hashMessage(timestamp, account, method, params string, nonce []byte) []byte
 	return second.Sum(nil)
 }
 
-// DefaultVerifyFunc provides a default verification function that uses public key recovery.
-// This function attempts to recover the public key from the signature and verify it matches
-// the expected account's public keys.
+// DefaultVerifyFunc only checks that the signatures are hex encoded. It does not
+// authenticate the account; use NewAuthorityVerifier for that.
 func DefaultVerifyFunc(message []byte, signatures []string, account string) error {
-	// This is a placeholder implementation.
-	// In a real implementation, you would:
-	// 1. Get the account's public keys from the blockchain
-	// 2. For each signature, recover the public key
-	// 3. Verify that the recovered public key matches one of the account's keys
-
 	if len(signatures) == 0 {
 		return errors.New("no signatures provided")
 	}
 
-	// For now, just validate that signatures are properly formatted hex strings
+	// Only validate that signatures are properly formatted hex strings
 	for i, sig := range signatures {
 		if _, err := hex.DecodeString(sig); err != nil {
 			return errors.Wrapf(err, "invalid signature format at index %d", i)
@@ -4,8 +4,12 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/steemit/steemutil/signer"
 )
 
 // Test constants
@@ -167,6 +171,103 @@ func TestValidateExpiredSignature(t *testing.T) {
 	}
 }
 
+func TestValidateFutureTimestamp(t *testing.T) {
+	request := &RpcRequest{
+		Method: testMethod,
+		Params: testParams,
+		ID:     1,
+	}
+
+	signedRequest, err := Sign(request, testAccount, []string{testPrivateKey})
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	now := time.Now().Add(-70 * time.Second)
+	_, err = Validate(signedRequest, DefaultVerifyFunc, WithClock(func() time.Time { return now }))
+	if err == nil {
+		t.Error("Expected error for a timestamp too far in the future")
+	}
+
+	_, err = Validate(signedRequest, DefaultVerifyFunc, WithClock(func() time.Time { return now }), WithMaxSkew(2*time.Minute))
+	if err != nil {
+		t.Errorf("Validate failed with a larger skew: %v", err)
+	}
+}
+
+func TestValidateMaxAge(t *testing.T) {
+	request := &RpcRequest{
+		Method: testMethod,
+		Params: testParams,
+		ID:     1,
+	}
+
+	signedRequest, err := Sign(request, testAccount, []string{testPrivateKey})
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	signedRequest.Params.Signed.Timestamp = time.Now().UTC().Add(-70 * time.Second).Format(time.RFC3339Nano)
+
+	if _, err := Validate(signedRequest, DefaultVerifyFunc, WithMaxAge(2*time.Minute)); err != nil {
+		t.Errorf("Validate failed with a larger max age: %v", err)
+	}
+}
+
+func TestValidateReplay(t *testing.T) {
+	request := &RpcRequest{
+		Method: testMethod,
+		Params: testParams,
+		ID:     1,
+	}
+
+	signedRequest, err := Sign(request, testAccount, []string{testPrivateKey})
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	store := NewMemoryNonceStore(16)
+	if _, err := Validate(signedRequest, DefaultVerifyFunc, WithNonceStore(store)); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if _, err := Validate(signedRequest, DefaultVerifyFunc, WithNonceStore(store)); err != ErrNonceReused {
+		t.Errorf("Expected %v, got %v", ErrNonceReused, err)
+	}
+
+	// The signature covers the decoded nonce, so an upper-cased copy is the
+	// same request.
+	for strings.ToUpper(signedRequest.Params.Signed.Nonce) == signedRequest.Params.Signed.Nonce {
+		if signedRequest, err = Sign(request, testAccount, []string{testPrivateKey}); err != nil {
+			t.Fatalf("Sign failed: %v", err)
+		}
+		if _, err := Validate(signedRequest, DefaultVerifyFunc, WithNonceStore(store)); err != nil {
+			t.Fatalf("Validate failed: %v", err)
+		}
+	}
+	upper := *signedRequest
+	upper.Params.Signed.Nonce = strings.ToUpper(signedRequest.Params.Signed.Nonce)
+	if _, err := Validate(&upper, DefaultVerifyFunc); err != nil {
+		t.Fatalf("Expected the upper-cased nonce to verify, got %v", err)
+	}
+	if _, err := Validate(&upper, DefaultVerifyFunc, WithNonceStore(store)); err != ErrNonceReused {
+		t.Errorf("Expected %v, got %v", ErrNonceReused, err)
+	}
+
+	// A request failing verification must not consume its nonce.
+	other, err := Sign(request, testAccount, []string{testPrivateKey})
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	reject := func(message []byte, signatures []string, account string) error {
+		return errors.New("rejected")
+	}
+	if _, err := Validate(other, reject, WithNonceStore(store)); err == nil {
+		t.Error("Expected verification error")
+	}
+	if _, err := Validate(other, DefaultVerifyFunc, WithNonceStore(store)); err != nil {
+		t.Errorf("Validate failed: %v", err)
+	}
+}
+
 func TestHashMessage(t *testing.T) {
 	timestamp := "2023-11-27T10:30:00.000Z"
 	account := "testuser"
@@ -318,3 +419,27 @@ func BenchmarkHashMessage(b *testing.B) {
 		hashMessage(timestamp, account, method, params, nonce)
 	}
 }
+
+func TestSignWith(t *testing.T) {
+	s, err := signer.FromWif(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := &RpcRequest{Method: testMethod, Params: testParams, ID: 1}
+	signedRequest, err := SignWith(request, testAccount, []signer.Signer{s})
+	if err != nil {
+		t.Fatalf("SignWith failed: %v", err)
+	}
+
+	signed := signedRequest.Params.Signed
+	nonce, _ := hex.DecodeString(signed.Nonce)
+	message := hashMessage(signed.Timestamp, signed.Account, testMethod, signed.Params, nonce)
+	sig, err := hex.DecodeString(signed.Signatures[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.PublicKey().VerifySha256(message, sig) {
+		t.Error("signature does not verify")
+	}
+}
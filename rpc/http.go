@@ -0,0 +1,188 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/signer"
+)
+
+// MaxRequestBodySize limits the request bodies read by Middleware.
+const MaxRequestBodySize = 1 << 20
+
+// VerifiedRequest is a signed request that passed Validate.
+type VerifiedRequest struct {
+	Account string
+	Method  string
+	ID      int
+
+	// Params is the signed params JSON, positional or an object.
+	Params json.RawMessage
+}
+
+type contextKey struct{}
+
+// NewContext returns a context carrying the verified request.
+func NewContext(ctx context.Context, req *VerifiedRequest) context.Context {
+	return context.WithValue(ctx, contextKey{}, req)
+}
+
+// FromContext returns the verified request stored by Middleware.
+func FromContext(ctx context.Context) (*VerifiedRequest, bool) {
+	req, ok := ctx.Value(contextKey{}).(*VerifiedRequest)
+	return req, ok
+}
+
+// AccountFromContext returns the account that signed the request.
+func AccountFromContext(ctx context.Context) (string, bool) {
+	req, ok := FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	return req.Account, true
+}
+
+// ParamsFromContext returns the params JSON of the signed request.
+func ParamsFromContext(ctx context.Context) (json.RawMessage, bool) {
+	req, ok := FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	return req.Params, true
+}
+
+// JSON-RPC error codes written by Middleware.
+const (
+	ErrCodeParse        = -32700
+	ErrCodeInvalid      = -32600
+	ErrCodeUnauthorized = -32001
+)
+
+type errorResponse struct {
+	JsonRpc string      `json:"jsonrpc"`
+	ID      interface{} `json:"id"`
+	Error   struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Middleware parses the body of every request as a SignedRequest, checks it
+// with Validate and passes the verified request to next in the request
+// context. Requests that fail are answered with a JSON-RPC error.
+func Middleware(verifyFunc VerifyFunc, opts ...ValidateOption) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				writeRPCError(w, http.StatusMethodNotAllowed, nil, ErrCodeInvalid, "method not allowed")
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, MaxRequestBodySize+1))
+			if err != nil {
+				writeRPCError(w, http.StatusBadRequest, nil, ErrCodeParse, "failed to read request")
+				return
+			}
+			if len(body) > MaxRequestBodySize {
+				writeRPCError(w, http.StatusRequestEntityTooLarge, nil, ErrCodeInvalid, "request too large")
+				return
+			}
+
+			var signed SignedRequest
+			if err := json.Unmarshal(body, &signed); err != nil {
+				writeRPCError(w, http.StatusBadRequest, nil, ErrCodeParse, "invalid signed request")
+				return
+			}
+
+			params, err := ValidateRaw(&signed, verifyFunc, opts...)
+			if err != nil {
+				writeRPCError(w, http.StatusUnauthorized, signed.ID, ErrCodeUnauthorized, err.Error())
+				return
+			}
+
+			ctx := NewContext(r.Context(), &VerifiedRequest{
+				Account: signed.Params.Signed.Account,
+				Method:  signed.Method,
+				ID:      signed.ID,
+				Params:  params,
+			})
+			r = r.WithContext(ctx)
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeRPCError(w http.ResponseWriter, status int, id interface{}, code int, msg string) {
+	res := &errorResponse{JsonRpc: "2.0", ID: id}
+	res.Error.Code = code
+	res.Error.Message = msg
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// SigningTransport is an http.RoundTripper that signs JSON-RPC request
+// bodies with Sign before sending them, for use with jsonrpc2.WithHTTPClient.
+type SigningTransport struct {
+	// Base sends the signed requests; http.DefaultTransport when nil.
+	Base    http.RoundTripper
+	Account string
+	Signers []signer.Signer
+}
+
+// NewSigningTransport creates a transport signing requests as account.
+func NewSigningTransport(base http.RoundTripper, account string, signers ...signer.Signer) *SigningTransport {
+	return &SigningTransport{Base: base, Account: account, Signers: signers}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *SigningTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil {
+		return nil, errors.New("cannot sign a request without body")
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read request body")
+	}
+
+	// Keep the params as sent, positional or an object, and sign them as is.
+	var raw struct {
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+		ID     int             `json:"id"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, errors.Wrap(err, "failed to decode JSON-RPC request")
+	}
+	call := RpcRequest{Method: raw.Method, ID: raw.ID}
+	if len(raw.Params) > 0 {
+		call.Params = raw.Params
+	}
+	signed, err := SignWith(&call, t.Account, t.Signers)
+	if err != nil {
+		return nil, err
+	}
+	signedBody, err := json.Marshal(signed)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode signed request")
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(signedBody))
+	out.ContentLength = int64(len(signedBody))
+	out.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(signedBody)), nil
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(out)
+}
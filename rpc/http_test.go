@@ -0,0 +1,156 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/steemit/steemutil/auth"
+	"github.com/steemit/steemutil/jsonrpc2"
+	"github.com/steemit/steemutil/signer"
+)
+
+func testServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	verifier := NewAuthorityVerifier(testLookup(t, testPrivateKey), auth.RolePosting)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, ok := FromContext(r.Context())
+		if !ok {
+			t.Error("expected a verified request in the context")
+			return
+		}
+		account, _ := AccountFromContext(r.Context())
+		params, _ := ParamsFromContext(r.Context())
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  map[string]interface{}{"account": account, "method": req.Method, "params": params},
+		})
+	})
+	return httptest.NewServer(Middleware(verifier, WithNonceStore(NewMemoryNonceStore(16)))(handler))
+}
+
+func TestSigningTransport(t *testing.T) {
+	server := testServer(t)
+	defer server.Close()
+
+	s, err := signer.FromWif(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpClient := &http.Client{Transport: NewSigningTransport(nil, testAccount, s)}
+	client := jsonrpc2.NewClient(server.URL, jsonrpc2.WithHTTPClient(httpClient))
+
+	for i := 0; i < 2; i++ {
+		if err := client.BuildSendData(testMethod, []any{[]string{"testuser"}}); err != nil {
+			t.Fatal(err)
+		}
+		res, err := client.Send()
+		if err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+		result, ok := res.Result.(map[string]interface{})
+		if !ok {
+			t.Fatalf("unexpected result: %v", res.Result)
+		}
+		if result["account"] != testAccount || result["method"] != testMethod {
+			t.Errorf("unexpected result: %v", result)
+		}
+	}
+}
+
+func TestSigningTransportObjectParams(t *testing.T) {
+	server := testServer(t)
+	defer server.Close()
+
+	s, err := signer.FromWif(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpClient := &http.Client{Transport: NewSigningTransport(nil, testAccount, s)}
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"rc_api.find_rc_accounts","params":{"accounts":["testuser"]}}`
+	res, err := httpClient.Post(server.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	defer res.Body.Close()
+	var out struct {
+		Result struct {
+			Account string          `json:"account"`
+			Params  json.RawMessage `json:"params"`
+		} `json:"result"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Result.Account != testAccount {
+		t.Errorf("expected %v, got %v", testAccount, out.Result.Account)
+	}
+	if string(out.Result.Params) != `{"accounts":["testuser"]}` {
+		t.Errorf("expected %v, got %v", `{"accounts":["testuser"]}`, string(out.Result.Params))
+	}
+}
+
+func TestValidateObjectParams(t *testing.T) {
+	request := &RpcRequest{Method: "rc_api.find_rc_accounts", Params: map[string]interface{}{"accounts": []string{"testuser"}}, ID: 1}
+	signedRequest, err := Sign(request, testAccount, []string{testPrivateKey})
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	params, err := ValidateRaw(signedRequest, DefaultVerifyFunc)
+	if err != nil {
+		t.Fatalf("ValidateRaw failed: %v", err)
+	}
+	if string(params) != `{"accounts":["testuser"]}` {
+		t.Errorf("expected %v, got %v", `{"accounts":["testuser"]}`, string(params))
+	}
+
+	// Validate only returns positional params.
+	if _, err := Validate(signedRequest, DefaultVerifyFunc); err == nil {
+		t.Error("expected Validate to reject object params")
+	}
+}
+
+func TestMiddlewareRejects(t *testing.T) {
+	server := testServer(t)
+	defer server.Close()
+
+	request := &RpcRequest{Method: testMethod, Params: testParams, ID: 7}
+	signedRequest, err := Sign(request, testAccount, []string{testPrivateKey})
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	signedRequest.Params.Signed.Account = "someoneelse"
+	forged, err := json.Marshal(signedRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		body   string
+		status int
+	}{
+		{"not json", http.StatusBadRequest},
+		{`{"jsonrpc":"2.0","method":"condenser_api.get_accounts","params":[["testuser"]],"id":1}`, http.StatusBadRequest},
+		{string(forged), http.StatusUnauthorized},
+	}
+	for _, tc := range testCases {
+		res, err := http.Post(server.URL, "application/json", strings.NewReader(tc.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var rpcErr errorResponse
+		err = json.NewDecoder(res.Body).Decode(&rpcErr)
+		res.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.StatusCode != tc.status || rpcErr.Error.Code == 0 {
+			t.Errorf("for %s, expected status %d with an error, got %d %+v", tc.body, tc.status, res.StatusCode, rpcErr)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNonceReused is returned by Validate when a request is replayed.
+var ErrNonceReused = errors.New("nonce already used")
+
+// ErrNonceStoreFull is returned by MemoryNonceStore.Add when every stored
+// nonce is still live.
+var ErrNonceStoreFull = errors.New("nonce store is full")
+
+// NonceStore remembers the nonces of validated requests.
+//
+// Add records key until expiresAt and reports whether it was new. It must be
+// atomic, so that two concurrent requests with the same nonce cannot both
+// succeed. External stores can implement it with e.g. Redis SET NX PX.
+type NonceStore interface {
+	Add(key string, expiresAt time.Time) (bool, error)
+}
+
+// MemoryNonceStore is an in-memory NonceStore holding at most a fixed number
+// of nonces. Only expired nonces are dropped; when every stored nonce is
+// still live Add fails with ErrNonceStoreFull, so the capacity should exceed
+// the number of requests expected within the validation window.
+type MemoryNonceStore struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+	now      func() time.Time
+}
+
+type nonceEntry struct {
+	key       string
+	expiresAt time.Time
+}
+
+// NewMemoryNonceStore creates an in-memory store for up to capacity nonces.
+func NewMemoryNonceStore(capacity int) *MemoryNonceStore {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &MemoryNonceStore{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element, capacity),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+// Add implements NonceStore.
+func (s *MemoryNonceStore) Add(key string, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if elem, ok := s.entries[key]; ok {
+		if now.Before(elem.Value.(*nonceEntry).expiresAt) {
+			return false, nil
+		}
+		s.remove(elem)
+	}
+
+	s.evictExpired(now)
+	if s.order.Len() >= s.capacity {
+		s.evictAllExpired(now)
+		if s.order.Len() >= s.capacity {
+			return false, ErrNonceStoreFull
+		}
+	}
+
+	s.entries[key] = s.order.PushBack(&nonceEntry{key: key, expiresAt: expiresAt})
+	return true, nil
+}
+
+// Len returns the number of stored nonces, including expired ones not yet
+// evicted.
+func (s *MemoryNonceStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+// evictExpired drops expired entries from the front of the list. Entries are
+// mostly added in expiry order, so this stops at the first live entry.
+func (s *MemoryNonceStore) evictExpired(now time.Time) {
+	for elem := s.order.Front(); elem != nil; elem = s.order.Front() {
+		if now.Before(elem.Value.(*nonceEntry).expiresAt) {
+			return
+		}
+		s.remove(elem)
+	}
+}
+
+// evictAllExpired drops every expired entry, including those behind live
+// entries with a later expiry.
+func (s *MemoryNonceStore) evictAllExpired(now time.Time) {
+	for elem := s.order.Front(); elem != nil; {
+		next := elem.Next()
+		if !now.Before(elem.Value.(*nonceEntry).expiresAt) {
+			s.remove(elem)
+		}
+		elem = next
+	}
+}
+
+func (s *MemoryNonceStore) remove(elem *list.Element) {
+	delete(s.entries, elem.Value.(*nonceEntry).key)
+	s.order.Remove(elem)
+}
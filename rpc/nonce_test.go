@@ -0,0 +1,55 @@
+package rpc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryNonceStore(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	store := NewMemoryNonceStore(2)
+	store.now = func() time.Time { return now }
+
+	if added, _ := store.Add("alice/1", now.Add(time.Minute)); !added {
+		t.Error("expected a new nonce to be added")
+	}
+	if added, _ := store.Add("alice/1", now.Add(time.Minute)); added {
+		t.Error("expected a reused nonce to be rejected")
+	}
+
+	// A full store never evicts live nonces, so flooding it cannot make a
+	// nonce reusable.
+	store.Add("alice/2", now.Add(time.Minute))
+	if added, err := store.Add("alice/3", now.Add(time.Minute)); added || err != ErrNonceStoreFull {
+		t.Errorf("expected %v, got %v, %v", ErrNonceStoreFull, added, err)
+	}
+	if added, _ := store.Add("alice/1", now.Add(time.Minute)); added {
+		t.Error("expected a live nonce to stay rejected")
+	}
+
+	// Expired nonces are dropped.
+	now = now.Add(2 * time.Minute)
+	if added, _ := store.Add("alice/3", now.Add(time.Minute)); !added {
+		t.Error("expected an expired nonce to be added again")
+	}
+	if store.Len() != 1 {
+		t.Errorf("expected 1, got %d", store.Len())
+	}
+}
+
+func TestMemoryNonceStoreEvictsExpiredOutOfOrder(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	store := NewMemoryNonceStore(2)
+	store.now = func() time.Time { return now }
+
+	// The expired nonce sits behind a live one with a later expiry.
+	store.Add("alice/1", now.Add(time.Hour))
+	store.Add("alice/2", now.Add(time.Minute))
+	now = now.Add(2 * time.Minute)
+	if added, err := store.Add("alice/3", now.Add(time.Minute)); !added || err != nil {
+		t.Errorf("expected the expired nonce to make room, got %v, %v", added, err)
+	}
+	if added, _ := store.Add("alice/1", now.Add(time.Minute)); added {
+		t.Error("expected a live nonce to stay rejected")
+	}
+}
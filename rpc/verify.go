@@ -0,0 +1,54 @@
+package rpc
+
+import (
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/auth"
+	"github.com/steemit/steemutil/wif"
+)
+
+// VerifyFunc verifies the signatures of a signed request for an account.
+type VerifyFunc func(message []byte, signatures []string, account string) error
+
+// ErrInsufficientAuthority is returned by the verifier of
+// NewAuthorityVerifier when the signatures do not satisfy the authority.
+var ErrInsufficientAuthority = errors.New("signatures do not satisfy the account authority")
+
+// NewAuthorityVerifier returns a VerifyFunc that recovers the public keys of
+// the signatures and checks them against the account's authority for role,
+// usually auth.RolePosting. Wrap lookup with auth.NewCachedAuthorityLookup
+// to avoid an API call per request.
+func NewAuthorityVerifier(lookup auth.AuthorityLookup, role string) VerifyFunc {
+	return func(message []byte, signatures []string, account string) error {
+		if len(signatures) == 0 {
+			return errors.New("no signatures provided")
+		}
+
+		keys := make([]*wif.PublicKey, 0, len(signatures))
+		for i, sigHex := range signatures {
+			sig, err := hex.DecodeString(sigHex)
+			if err != nil {
+				return errors.Wrapf(err, "invalid signature format at index %d", i)
+			}
+			key, err := wif.RecoverPublicKeyFromSignature(message, sig)
+			if err != nil {
+				return errors.Wrapf(err, "invalid signature at index %d", i)
+			}
+			keys = append(keys, key)
+		}
+
+		authority, err := lookup.Authority(account, role)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get %s authority of %s", role, account)
+		}
+		_, ok, err := auth.AuthorityWeight(authority, keys)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrInsufficientAuthority
+		}
+		return nil
+	}
+}
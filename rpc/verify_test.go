@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/auth"
+	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/wif"
+)
+
+func testLookup(t *testing.T, keyWif string) auth.AuthorityLookup {
+	t.Helper()
+	pubKey, err := auth.WifToPublic(keyWif)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return auth.AuthorityLookupFunc(func(account, role string) (*protocol.Authority, error) {
+		if account != testAccount || role != auth.RolePosting {
+			return nil, auth.ErrAccountNotFound
+		}
+		return auth.NewKeyAuthority(pubKey), nil
+	})
+}
+
+func TestAuthorityVerifier(t *testing.T) {
+	request := &RpcRequest{
+		Method: testMethod,
+		Params: testParams,
+		ID:     1,
+	}
+	signedRequest, err := Sign(request, testAccount, []string{testPrivateKey})
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	verifier := NewAuthorityVerifier(testLookup(t, testPrivateKey), auth.RolePosting)
+	if _, err := Validate(signedRequest, verifier); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	other, err := wif.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherVerifier := NewAuthorityVerifier(testLookup(t, other.ToWif()), auth.RolePosting)
+	if _, err := Validate(signedRequest, otherVerifier); errors.Cause(err) != ErrInsufficientAuthority {
+		t.Errorf("Expected %v, got %v", ErrInsufficientAuthority, err)
+	}
+
+	// Changing the account invalidates the signature.
+	signedRequest.Params.Signed.Account = "someoneelse"
+	if _, err := Validate(signedRequest, verifier); err == nil {
+		t.Error("Expected error for another account")
+	}
+}
+
+func TestAuthorityVerifierInvalidSignature(t *testing.T) {
+	verifier := NewAuthorityVerifier(testLookup(t, testPrivateKey), auth.RolePosting)
+	message := make([]byte, 32)
+	for _, signatures := range [][]string{nil, {"zz"}, {"00"}} {
+		if err := verifier(message, signatures, testAccount); err == nil {
+			t.Errorf("Expected error for %v", signatures)
+		}
+	}
+}
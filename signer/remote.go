@@ -0,0 +1,183 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/wif"
+)
+
+// Remote signer protocol. All requests and responses are JSON:
+//
+//	GET  /keys          -> {"keys": ["STM..."]}
+//	POST /sign          {"public_key": "STM...", "digest": "<hex>"}
+//	                    -> {"signature": "<hex>"}
+//	POST /shared_secret {"public_key": "STM...", "other": "STM..."}
+//	                    -> {"secret": "<hex>"}
+//
+// Errors are returned with a non-2xx status and {"error": "..."}.
+const (
+	PathKeys         = "/keys"
+	PathSign         = "/sign"
+	PathSharedSecret = "/shared_secret"
+)
+
+type keysResponse struct {
+	Keys []string `json:"keys"`
+}
+
+type signRequest struct {
+	PublicKey string `json:"public_key"`
+	Digest    string `json:"digest"`
+}
+
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+type sharedSecretRequest struct {
+	PublicKey string `json:"public_key"`
+	Other     string `json:"other"`
+}
+
+type sharedSecretResponse struct {
+	Secret string `json:"secret"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// RemoteSigner signs with a key held by a signer process speaking the
+// remote signer protocol, e.g. one running Server.
+type RemoteSigner struct {
+	client  *http.Client
+	baseURL string
+	pub     *wif.PublicKey
+}
+
+// NewRemoteSigner creates a signer for the given public key served at
+// baseURL. If client is nil, http.DefaultClient is used.
+func NewRemoteSigner(baseURL string, client *http.Client, pubKey string) (*RemoteSigner, error) {
+	pub := &wif.PublicKey{}
+	if err := pub.FromStrAnyPrefix(pubKey); err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &RemoteSigner{client: client, baseURL: strings.TrimRight(baseURL, "/"), pub: pub}, nil
+}
+
+// RemoteSigners returns a signer for every key served at baseURL.
+func RemoteSigners(baseURL string, client *http.Client) ([]*RemoteSigner, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var resp keysResponse
+	if err := call(client, http.MethodGet, strings.TrimRight(baseURL, "/")+PathKeys, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	signers := make([]*RemoteSigner, 0, len(resp.Keys))
+	for _, key := range resp.Keys {
+		s, err := NewRemoteSigner(baseURL, client, key)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, s)
+	}
+	return signers, nil
+}
+
+// UnixSocketClient returns an HTTP client that sends every request to the
+// Unix socket at path. Use it with a base URL such as "http://signer".
+func UnixSocketClient(path string) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		},
+	}
+}
+
+func (s *RemoteSigner) PublicKey() *wif.PublicKey {
+	return s.pub
+}
+
+func (s *RemoteSigner) SignDigest(digest []byte) ([]byte, error) {
+	if len(digest) != 32 {
+		return nil, errors.Errorf("digest must be 32 bytes, got %d", len(digest))
+	}
+
+	req := &signRequest{PublicKey: s.pub.ToStr(), Digest: hex.EncodeToString(digest)}
+	var resp signResponse
+	if err := call(s.client, http.MethodPost, s.baseURL+PathSign, req, &resp); err != nil {
+		return nil, err
+	}
+
+	sig, err := hex.DecodeString(resp.Signature)
+	if err != nil || len(sig) != 65 {
+		return nil, errors.New("remote signer returned an invalid signature")
+	}
+	return sig, nil
+}
+
+func (s *RemoteSigner) SharedSecret(pub *wif.PublicKey) ([]byte, error) {
+	req := &sharedSecretRequest{PublicKey: s.pub.ToStr(), Other: pub.ToStr()}
+	var resp sharedSecretResponse
+	if err := call(s.client, http.MethodPost, s.baseURL+PathSharedSecret, req, &resp); err != nil {
+		return nil, err
+	}
+
+	secret, err := hex.DecodeString(resp.Secret)
+	if err != nil || len(secret) != 64 {
+		return nil, errors.New("remote signer returned an invalid shared secret")
+	}
+	return secret, nil
+}
+
+func call(client *http.Client, method, url string, body, result interface{}) error {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return errors.Wrap(err, "failed to encode request")
+		}
+	}
+
+	req, err := http.NewRequest(method, url, &reqBody)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "remote signer request failed")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var e errorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&e); err == nil && e.Error != "" {
+			return errors.Errorf("remote signer: %s", e.Error)
+		}
+		return errors.Errorf("remote signer: unexpected status %s", resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return errors.Wrap(err, "failed to decode remote signer response")
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package signer
+
+import (
+	"crypto/sha256"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steemit/steemutil/wif"
+)
+
+func newTestSigners(t *testing.T) []Signer {
+	a, err := wif.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := wif.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return FromKeys([]*wif.PrivateKey{a, b})
+}
+
+func TestRemoteSigner(t *testing.T) {
+	local := newTestSigners(t)
+	handler := NewServer(local...)
+	handler.AllowSharedSecret = true
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	remote, err := RemoteSigners(server.URL, nil)
+	if err != nil {
+		t.Fatalf("RemoteSigners failed: %v", err)
+	}
+	if len(remote) != 2 {
+		t.Fatalf("expected 2 signers, got %d", len(remote))
+	}
+
+	digest := sha256.Sum256([]byte("message"))
+	for _, s := range remote {
+		sig, err := s.SignDigest(digest[:])
+		if err != nil {
+			t.Fatalf("SignDigest failed: %v", err)
+		}
+		if !s.PublicKey().VerifySha256(digest[:], sig) {
+			t.Error("signature does not verify")
+		}
+	}
+
+	other := local[0].PublicKey()
+	remoteSigner, err := NewRemoteSigner(server.URL, nil, local[1].PublicKey().ToStr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret, err := remoteSigner.SharedSecret(other)
+	if err != nil {
+		t.Fatalf("SharedSecret failed: %v", err)
+	}
+	expected, _ := local[1].(*KeySigner).SharedSecret(other)
+	if string(secret) != string(expected) {
+		t.Error("unexpected shared secret")
+	}
+}
+
+func TestRemoteSigner_Errors(t *testing.T) {
+	local := newTestSigners(t)
+	server := NewServer(local[0])
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	unknown, err := NewRemoteSigner(ts.URL, nil, local[1].PublicKey().ToStr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("message"))
+	if _, err := unknown.SignDigest(digest[:]); err == nil {
+		t.Error("expected error for unknown key")
+	}
+
+	known, err := NewRemoteSigner(ts.URL, nil, local[0].PublicKey().ToStr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := known.SharedSecret(local[1].PublicKey()); err == nil {
+		t.Error("expected error when shared secrets are disabled")
+	}
+}
+
+func TestServer_RequestTooLarge(t *testing.T) {
+	local := newTestSigners(t)
+	ts := httptest.NewServer(NewServer(local...))
+	defer ts.Close()
+
+	body := `{"public_key":"` + strings.Repeat("a", MaxRequestBodySize) + `"}`
+	resp, err := http.Post(ts.URL+PathSign, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected %d, got %d", http.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestRemoteSigner_UnixSocket(t *testing.T) {
+	local := newTestSigners(t)
+	path := filepath.Join(t.TempDir(), "signer.sock")
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	server := &http.Server{Handler: NewServer(local...)}
+	go server.Serve(listener)
+	defer server.Close()
+
+	s, err := NewRemoteSigner("http://signer", UnixSocketClient(path), local[0].PublicKey().ToStr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("message"))
+	sig, err := s.SignDigest(digest[:])
+	if err != nil {
+		t.Fatalf("SignDigest failed: %v", err)
+	}
+	if !local[0].PublicKey().VerifySha256(digest[:], sig) {
+		t.Error("signature does not verify")
+	}
+}
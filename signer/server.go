@@ -0,0 +1,150 @@
+package signer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/steemit/steemutil/wif"
+)
+
+// MaxRequestBodySize limits the request bodies read by Server.
+const MaxRequestBodySize = 1 << 16
+
+// Server serves the remote signer protocol for a set of signers. It is
+// meant to run in an isolated process, listening on a Unix socket or a
+// loopback address, and does no authentication of its own.
+type Server struct {
+	// signers is indexed by the compressed public key.
+	signers map[string]Signer
+
+	// AllowSharedSecret enables the shared_secret endpoint used for memos.
+	// It is off by default, since a shared secret decrypts every memo
+	// exchanged between the two keys.
+	AllowSharedSecret bool
+}
+
+// NewServer creates a server for the given signers. Set AllowSharedSecret
+// to also serve shared secrets.
+func NewServer(signers ...Signer) *Server {
+	s := &Server{signers: make(map[string]Signer, len(signers))}
+	for _, signer := range signers {
+		s.signers[string(signer.PublicKey().ToByte())] = signer
+	}
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxRequestBodySize)
+	switch r.URL.Path {
+	case PathKeys:
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		s.handleKeys(w)
+	case PathSign:
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		s.handleSign(w, r)
+	case PathSharedSecret:
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		s.handleSharedSecret(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) handleKeys(w http.ResponseWriter) {
+	keys := make([]string, 0, len(s.signers))
+	for _, signer := range s.signers {
+		keys = append(keys, signer.PublicKey().ToStr())
+	}
+	sort.Strings(keys)
+	writeJSON(w, http.StatusOK, &keysResponse{Keys: keys})
+}
+
+func (s *Server) handleSign(w http.ResponseWriter, r *http.Request) {
+	var req signRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	signer, ok := s.lookup(req.PublicKey)
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown public key")
+		return
+	}
+
+	digest, err := hex.DecodeString(req.Digest)
+	if err != nil || len(digest) != 32 {
+		writeError(w, http.StatusBadRequest, "digest must be 32 hex encoded bytes")
+		return
+	}
+
+	sig, err := signer.SignDigest(digest)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, &signResponse{Signature: hex.EncodeToString(sig)})
+}
+
+func (s *Server) handleSharedSecret(w http.ResponseWriter, r *http.Request) {
+	if !s.AllowSharedSecret {
+		writeError(w, http.StatusForbidden, "shared secrets are disabled")
+		return
+	}
+
+	var req sharedSecretRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	found, _ := s.lookup(req.PublicKey)
+	signer, ok := found.(SharedSecretSigner)
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown public key")
+		return
+	}
+
+	other := &wif.PublicKey{}
+	if err := other.FromStrAnyPrefix(req.Other); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid public key")
+		return
+	}
+
+	secret, err := signer.SharedSecret(other)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, &sharedSecretResponse{Secret: hex.EncodeToString(secret)})
+}
+
+func (s *Server) lookup(pubKey string) (Signer, bool) {
+	pub := &wif.PublicKey{}
+	if err := pub.FromStrAnyPrefix(pubKey); err != nil {
+		return nil, false
+	}
+	signer, ok := s.signers[string(pub.ToByte())]
+	return signer, ok
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, &errorResponse{Error: msg})
+}
@@ -0,0 +1,82 @@
+// Package signer abstracts where private keys live.
+//
+// A Signer exposes a public key and signs 32-byte digests. Transactions,
+// signed RPC requests and memos accept signers, so keys can be kept in
+// memory, in a keystore or in a separate process reached through
+// RemoteSigner.
+package signer
+
+import (
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/wif"
+)
+
+// Signer signs digests with a single private key.
+type Signer interface {
+	// PublicKey returns the public key of the signing key.
+	PublicKey() *wif.PublicKey
+
+	// SignDigest signs a 32-byte digest and returns the 65-byte compact
+	// signature used by Steem.
+	SignDigest(digest []byte) ([]byte, error)
+}
+
+// SharedSecretSigner is a Signer that can also compute the ECDH shared
+// secret needed to encrypt and decrypt memos.
+type SharedSecretSigner interface {
+	Signer
+
+	// SharedSecret returns the SHA-512 hashed ECDH shared secret with the
+	// given public key.
+	SharedSecret(pub *wif.PublicKey) ([]byte, error)
+}
+
+// KeySigner is a Signer backed by a private key held in memory.
+type KeySigner struct {
+	key *wif.PrivateKey
+	pub *wif.PublicKey
+}
+
+// NewKeySigner creates a signer for the private key.
+func NewKeySigner(key *wif.PrivateKey) *KeySigner {
+	return &KeySigner{key: key, pub: key.ToPublicKey()}
+}
+
+// FromWif creates a signer for a private key in WIF format.
+func FromWif(privWif string) (*KeySigner, error) {
+	key := &wif.PrivateKey{}
+	if err := key.FromWif(privWif); err != nil {
+		return nil, err
+	}
+	return NewKeySigner(key), nil
+}
+
+// FromKeys wraps each private key in a KeySigner.
+func FromKeys(keys []*wif.PrivateKey) []Signer {
+	signers := make([]Signer, 0, len(keys))
+	for _, key := range keys {
+		signers = append(signers, NewKeySigner(key))
+	}
+	return signers
+}
+
+func (s *KeySigner) PublicKey() *wif.PublicKey {
+	return s.pub
+}
+
+func (s *KeySigner) SignDigest(digest []byte) ([]byte, error) {
+	if len(digest) != 32 {
+		return nil, errors.Errorf("digest must be 32 bytes, got %d", len(digest))
+	}
+
+	sig, err := ecdsa.SignCompact(s.key.Raw.PrivKey, digest, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create compact signature")
+	}
+	return sig, nil
+}
+
+func (s *KeySigner) SharedSecret(pub *wif.PublicKey) ([]byte, error) {
+	return s.key.SharedSecret(pub), nil
+}
@@ -0,0 +1,42 @@
+package signer
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/steemit/steemutil/wif"
+)
+
+const testWif = "5JWHY5DxTF6qN5grTtChDCYBmWHfY9zaSsw4CxEKN5eZpH9iBma"
+
+func TestKeySigner(t *testing.T) {
+	s, err := FromWif(testWif)
+	if err != nil {
+		t.Fatalf("FromWif failed: %v", err)
+	}
+
+	key := &wif.PrivateKey{}
+	if err := key.FromWif(testWif); err != nil {
+		t.Fatal(err)
+	}
+	if s.PublicKey().ToStr() != key.ToPubKeyStr() {
+		t.Errorf("expected %v, got %v", key.ToPubKeyStr(), s.PublicKey().ToStr())
+	}
+
+	digest := sha256.Sum256([]byte("message"))
+	sig, err := s.SignDigest(digest[:])
+	if err != nil {
+		t.Fatalf("SignDigest failed: %v", err)
+	}
+	if !s.PublicKey().VerifySha256(digest[:], sig) {
+		t.Error("signature does not verify")
+	}
+
+	if _, err := s.SignDigest([]byte("short")); err == nil {
+		t.Error("expected error for short digest")
+	}
+
+	if _, err := FromWif("invalid"); err == nil {
+		t.Error("expected error for invalid WIF")
+	}
+}
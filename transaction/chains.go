@@ -1,13 +1,23 @@
 package transaction
 
+import "github.com/steemit/steemutil/consts"
+
 type Chain struct {
 	ID string
-}
 
-var SteemChain = &Chain{
-	ID: "0000000000000000000000000000000000000000000000000000000000000000",
+	// Config holds the remaining chain parameters. It is nil for chains
+	// created from an id alone.
+	Config *consts.ChainConfig
 }
 
-var TestChain = &Chain{
-	ID: "18dcf0a285365fc58b71f18b3d3fec954aa0c141c44e4e5cb4cf777b9eab274e",
+var SteemChain = NewChain(consts.SteemMainnet)
+
+var TestChain = NewChain(consts.SteemTestnet)
+
+// NewChain returns the chain described by the given configuration.
+func NewChain(cfg *consts.ChainConfig) *Chain {
+	return &Chain{
+		ID:     cfg.ChainID,
+		Config: cfg,
+	}
 }
@@ -0,0 +1,253 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/signer"
+	"github.com/steemit/steemutil/wif"
+)
+
+// RequiredAuthority is an authority whose keys are expected to sign a
+// transaction, e.g. the active authority of a multi-sig account.
+type RequiredAuthority struct {
+	Account   string              `json:"account"`
+	Level     string              `json:"level"`
+	Authority *protocol.Authority `json:"authority"`
+}
+
+// AuthorityStatus reports how far a RequiredAuthority is satisfied.
+type AuthorityStatus struct {
+	Account   string
+	Level     string
+	Threshold uint32
+	Weight    uint32
+	Satisfied bool
+
+	// SignedKeys and MissingKeys list the authority's keys that did and did
+	// not sign. Account auths are not resolved and do not add weight.
+	SignedKeys  []string
+	MissingKeys []string
+}
+
+// SignatureStatus reports which authorities of a transaction are satisfied.
+type SignatureStatus struct {
+	Authorities []AuthorityStatus
+
+	// Complete is true when every required authority is satisfied.
+	Complete bool
+}
+
+// PartialTransaction is the exchange format for a transaction passed between
+// several parties for signing.
+type PartialTransaction struct {
+	ChainID     string              `json:"chain_id"`
+	Transaction *Transaction        `json:"transaction"`
+	Expected    []RequiredAuthority `json:"expected_signers"`
+}
+
+// NewPartialTransaction wraps an unsigned or partially signed transaction for
+// the given chain together with the authorities expected to sign it.
+func NewPartialTransaction(tx *SignedTransaction, chain *Chain, expected []RequiredAuthority) *PartialTransaction {
+	return &PartialTransaction{
+		ChainID:     chain.ID,
+		Transaction: tx.Transaction,
+		Expected:    expected,
+	}
+}
+
+// ParsePartialTransaction decodes a partial transaction exported with Export.
+// Signatures that do not recover to a key are rejected.
+func ParsePartialTransaction(data []byte) (*PartialTransaction, error) {
+	var p PartialTransaction
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, errors.Wrap(err, "failed to decode partial transaction")
+	}
+	if p.Transaction == nil {
+		return nil, errors.New("partial transaction has no transaction")
+	}
+	if _, err := hex.DecodeString(p.ChainID); err != nil || len(p.ChainID) != 64 {
+		return nil, errors.Errorf("invalid chain id: %s", p.ChainID)
+	}
+	if err := p.Signed().Deduplicate(p.Chain()); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Export encodes the partial transaction as JSON.
+func (p *PartialTransaction) Export() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// Chain returns the chain the transaction is signed for.
+func (p *PartialTransaction) Chain() *Chain {
+	return &Chain{ID: p.ChainID}
+}
+
+// Signed returns the transaction as a SignedTransaction.
+func (p *PartialTransaction) Signed() *SignedTransaction {
+	return &SignedTransaction{p.Transaction}
+}
+
+// Sign adds the signatures of the given signers.
+func (p *PartialTransaction) Sign(signers []signer.Signer) error {
+	return p.Signed().AppendSignatures(signers, p.Chain())
+}
+
+// Status reports which expected authorities are still missing signatures.
+func (p *PartialTransaction) Status() (*SignatureStatus, error) {
+	return p.Signed().SignatureStatus(p.Chain(), p.Expected)
+}
+
+// AppendSignatures signs the transaction with the given signers and appends
+// the signatures to the existing ones. A key that already signed is skipped.
+func (tx *SignedTransaction) AppendSignatures(signers []signer.Signer, chain *Chain) error {
+	digest, err := tx.Digest(chain)
+	if err != nil {
+		return err
+	}
+
+	signed, err := tx.recoverKeys(digest)
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(signed))
+	for _, key := range signed {
+		seen[key.ToStr()] = true
+	}
+
+	for _, s := range signers {
+		pub := s.PublicKey().ToStr()
+		if seen[pub] {
+			continue
+		}
+
+		sig, err := s.SignDigest(digest)
+		if err != nil {
+			return err
+		}
+		tx.Signatures = append(tx.Signatures, hex.EncodeToString(sig))
+		seen[pub] = true
+	}
+	return nil
+}
+
+// Deduplicate removes signatures made by a key that already signed.
+func (tx *SignedTransaction) Deduplicate(chain *Chain) error {
+	digest, err := tx.Digest(chain)
+	if err != nil {
+		return err
+	}
+
+	keys, err := tx.recoverKeys(digest)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(keys))
+	sigs := tx.Signatures[:0]
+	for i, key := range keys {
+		if seen[key.ToStr()] {
+			continue
+		}
+		seen[key.ToStr()] = true
+		sigs = append(sigs, tx.Signatures[i])
+	}
+	tx.Signatures = sigs
+	return nil
+}
+
+// SignedKeys returns the public keys that signed the transaction, in
+// signature order and without duplicates.
+func (tx *SignedTransaction) SignedKeys(chain *Chain) ([]*wif.PublicKey, error) {
+	digest, err := tx.Digest(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := tx.recoverKeys(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(keys))
+	result := make([]*wif.PublicKey, 0, len(keys))
+	for _, key := range keys {
+		if !seen[key.ToStr()] {
+			seen[key.ToStr()] = true
+			result = append(result, key)
+		}
+	}
+	return result, nil
+}
+
+// SignatureStatus checks the signatures of the transaction against the
+// expected authorities.
+func (tx *SignedTransaction) SignatureStatus(chain *Chain, expected []RequiredAuthority) (*SignatureStatus, error) {
+	keys, err := tx.SignedKeys(chain)
+	if err != nil {
+		return nil, err
+	}
+	signed := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		signed[string(key.ToByte())] = true
+	}
+
+	status := &SignatureStatus{Complete: true}
+	for _, req := range expected {
+		if req.Authority == nil {
+			return nil, errors.Errorf("missing authority for %s/%s", req.Account, req.Level)
+		}
+
+		auth := AuthorityStatus{
+			Account:   req.Account,
+			Level:     req.Level,
+			Threshold: req.Authority.WeightThreshold,
+		}
+
+		pubKeys := make([]string, 0, len(req.Authority.KeyAuths))
+		for pubKey := range req.Authority.KeyAuths {
+			pubKeys = append(pubKeys, pubKey)
+		}
+		sort.Strings(pubKeys)
+
+		for _, pubKey := range pubKeys {
+			key := &wif.PublicKey{}
+			if err := key.FromStrAnyPrefix(pubKey); err != nil {
+				return nil, errors.Wrapf(err, "invalid key in authority of %s/%s", req.Account, req.Level)
+			}
+			if signed[string(key.ToByte())] {
+				auth.Weight += uint32(req.Authority.KeyAuths[pubKey])
+				auth.SignedKeys = append(auth.SignedKeys, pubKey)
+			} else {
+				auth.MissingKeys = append(auth.MissingKeys, pubKey)
+			}
+		}
+
+		auth.Satisfied = auth.Weight >= auth.Threshold
+		status.Complete = status.Complete && auth.Satisfied
+		status.Authorities = append(status.Authorities, auth)
+	}
+	return status, nil
+}
+
+// recoverKeys recovers the public key of every signature.
+func (tx *SignedTransaction) recoverKeys(digest []byte) ([]*wif.PublicKey, error) {
+	keys := make([]*wif.PublicKey, 0, len(tx.Signatures))
+	for i, sigHex := range tx.Signatures {
+		sig, err := hex.DecodeString(sigHex)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid signature at index %d", i)
+		}
+		key, err := wif.RecoverPublicKeyFromSignature(digest, sig)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid signature at index %d", i)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
@@ -0,0 +1,160 @@
+package transaction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/signer"
+	"github.com/steemit/steemutil/wif"
+)
+
+func newPartialTestTransaction() *SignedTransaction {
+	expiration := time.Date(2016, 8, 8, 12, 24, 17, 0, time.UTC)
+	tx := &Transaction{
+		RefBlockNum:    36029,
+		RefBlockPrefix: 1164960351,
+		Expiration:     &protocol.Time{Time: &expiration},
+		Extensions:     []interface{}{},
+	}
+	tx.PushOperation(&protocol.TransferOperation{
+		From:   "multisig",
+		To:     "bob",
+		Amount: "1.000 STEEM",
+		Memo:   "",
+	})
+	return NewSignedTransaction(tx)
+}
+
+func newTestKeys(t *testing.T, n int) []*wif.PrivateKey {
+	keys := make([]*wif.PrivateKey, 0, n)
+	for i := 0; i < n; i++ {
+		key, err := wif.GeneratePrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestPartialTransaction_Flow(t *testing.T) {
+	keys := newTestKeys(t, 3)
+	expected := []RequiredAuthority{{
+		Account: "multisig",
+		Level:   "active",
+		Authority: &protocol.Authority{
+			AccountAuths: protocol.StringInt64Map{},
+			KeyAuths: protocol.StringInt64Map{
+				keys[0].ToPubKeyStr(): 1,
+				keys[1].ToPubKeyStr(): 1,
+				keys[2].ToPubKeyStr(): 1,
+			},
+			WeightThreshold: 2,
+		},
+	}}
+
+	// The first party signs and exports the transaction.
+	first := NewPartialTransaction(newPartialTestTransaction(), TestChain, expected)
+	if err := first.Sign(signer.FromKeys(keys[:1])); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	status, err := first.Status()
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if status.Complete || status.Authorities[0].Weight != 1 || len(status.Authorities[0].MissingKeys) != 2 {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	data, err := first.Export()
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	// The second party imports it, signs twice and checks the status.
+	second, err := ParsePartialTransaction(data)
+	if err != nil {
+		t.Fatalf("ParsePartialTransaction failed: %v", err)
+	}
+	if second.ChainID != TestChain.ID {
+		t.Errorf("expected %v, got %v", TestChain.ID, second.ChainID)
+	}
+	if err := second.Sign(signer.FromKeys([]*wif.PrivateKey{keys[0], keys[1], keys[1]})); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if len(second.Transaction.Signatures) != 2 {
+		t.Errorf("expected 2 signatures, got %d", len(second.Transaction.Signatures))
+	}
+
+	status, err = second.Status()
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if !status.Complete || !status.Authorities[0].Satisfied || status.Authorities[0].Weight != 2 {
+		t.Errorf("unexpected status: %+v", status)
+	}
+	if len(status.Authorities[0].MissingKeys) != 1 || status.Authorities[0].MissingKeys[0] != keys[2].ToPubKeyStr() {
+		t.Errorf("unexpected missing keys: %v", status.Authorities[0].MissingKeys)
+	}
+
+	ok, err := second.Signed().Verify([]*wif.PublicKey{keys[0].ToPublicKey(), keys[1].ToPublicKey()}, TestChain)
+	if err != nil || !ok {
+		t.Errorf("expected both keys to verify, got %v, %v", ok, err)
+	}
+	ok, err = second.Signed().Verify([]*wif.PublicKey{keys[2].ToPublicKey()}, TestChain)
+	if err != nil || ok {
+		t.Errorf("expected the missing key not to verify, got %v, %v", ok, err)
+	}
+}
+
+func TestSignedTransaction_Deduplicate(t *testing.T) {
+	keys := newTestKeys(t, 2)
+	stx := newPartialTestTransaction()
+
+	if err := stx.SignWith(signer.FromKeys([]*wif.PrivateKey{keys[0], keys[1], keys[0]}), TestChain); err != nil {
+		t.Fatal(err)
+	}
+	if len(stx.Signatures) != 3 {
+		t.Fatalf("expected 3 signatures, got %d", len(stx.Signatures))
+	}
+
+	if err := stx.Deduplicate(TestChain); err != nil {
+		t.Fatalf("Deduplicate failed: %v", err)
+	}
+	if len(stx.Signatures) != 2 {
+		t.Errorf("expected 2 signatures, got %d", len(stx.Signatures))
+	}
+
+	signed, err := stx.SignedKeys(TestChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signed) != 2 || signed[0].ToStr() != keys[0].ToPubKeyStr() || signed[1].ToStr() != keys[1].ToPubKeyStr() {
+		t.Errorf("unexpected signed keys: %v", signed)
+	}
+}
+
+func TestParsePartialTransaction_Invalid(t *testing.T) {
+	tests := []string{
+		`{"chain_id": "00"}`,
+		`{"chain_id": "zz", "transaction": {}}`,
+		`not json`,
+	}
+	for _, data := range tests {
+		if _, err := ParsePartialTransaction([]byte(data)); err == nil {
+			t.Errorf("expected error for %s", data)
+		}
+	}
+
+	p := NewPartialTransaction(newPartialTestTransaction(), TestChain, nil)
+	p.Transaction.Signatures = []string{"00"}
+	data, err := p.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParsePartialTransaction(data); err == nil {
+		t.Error("expected error for an invalid signature")
+	}
+}
@@ -6,9 +6,9 @@ import (
 	"encoding/hex"
 	"time"
 
-	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	"github.com/steemit/steemutil/encoder"
 	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/signer"
 	"github.com/steemit/steemutil/wif"
 
 	"github.com/pkg/errors"
@@ -66,7 +66,16 @@ func (tx *SignedTransaction) Digest(chain *Chain) ([]byte, error) {
 	return digest[:], nil
 }
 
+// Sign signs the transaction with the given keys, replacing any existing
+// signatures. Use AppendSignatures to add signatures to a partially signed
+// transaction.
 func (tx *SignedTransaction) Sign(privKeys []*wif.PrivateKey, chain *Chain) error {
+	return tx.SignWith(signer.FromKeys(privKeys), chain)
+}
+
+// SignWith signs the transaction with the given signers, wherever their keys
+// are held, replacing any existing signatures.
+func (tx *SignedTransaction) SignWith(signers []signer.Signer, chain *Chain) error {
 	// Compute digest
 	digest, err := tx.Digest(chain)
 	if err != nil {
@@ -74,41 +83,36 @@ func (tx *SignedTransaction) Sign(privKeys []*wif.PrivateKey, chain *Chain) erro
 	}
 
 	// Sign digest
-	sigs := make([][]byte, 0, len(privKeys))
-	for _, v := range privKeys {
-		sig, err := ecdsa.SignCompact(v.Raw.PrivKey, digest, true)
+	sigsHex := make([]string, 0, len(signers))
+	for _, s := range signers {
+		sig, err := s.SignDigest(digest)
 		if err != nil {
 			return err
 		}
-		sigs = append(sigs, sig)
-	}
-
-	// Set the signature array in the transaction.
-	sigsHex := make([]string, 0, len(sigs))
-	for _, sig := range sigs {
 		sigsHex = append(sigsHex, hex.EncodeToString(sig))
 	}
 
+	// Set the signature array in the transaction.
 	tx.Transaction.Signatures = sigsHex
 	return nil
 }
 
+// Verify reports whether every given public key has signed the transaction.
 func (tx *SignedTransaction) Verify(pubKeys []*wif.PublicKey, chain *Chain) (bool, error) {
-	// Compute digest
-	digest, err := tx.Digest(chain)
+	signed, err := tx.SignedKeys(chain)
 	if err != nil {
 		return false, err
 	}
 
-	// Parse signatures
-	sigs := make([][]byte, 0, len(tx.Signatures))
-	for i, sig := range sigs {
-		tmpSig, err := ecdsa.ParseSignature(sig)
-		if err != nil {
-			return false, err
+	for _, pubKey := range pubKeys {
+		found := false
+		for _, key := range signed {
+			if bytes.Equal(key.ToByte(), pubKey.ToByte()) {
+				found = true
+				break
+			}
 		}
-		verified := tmpSig.Verify(digest, pubKeys[i].Raw)
-		if !verified {
+		if !found {
 			return false, nil
 		}
 	}
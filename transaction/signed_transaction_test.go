@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/steemit/steemutil/protocol"
+	"github.com/steemit/steemutil/signer"
 	"github.com/steemit/steemutil/wif"
 )
 
@@ -96,3 +97,23 @@ func TestTransaction_SignAndVerify(t *testing.T) {
 		t.Error("verification failed")
 	}
 }
+
+func TestTransaction_SignWith(t *testing.T) {
+	tx.Signatures = nil
+	defer func() {
+		tx.Signatures = nil
+	}()
+
+	stx := NewSignedTransaction(tx)
+	if err := stx.Sign(privateKeys, SteemChain); err != nil {
+		t.Fatal(err)
+	}
+	expected := tx.Signatures[0]
+
+	if err := stx.SignWith(signer.FromKeys(privateKeys), SteemChain); err != nil {
+		t.Fatal(err)
+	}
+	if len(tx.Signatures) != 1 || tx.Signatures[0] != expected {
+		t.Errorf("expected %v, got %v", expected, tx.Signatures)
+	}
+}
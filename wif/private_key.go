@@ -1,6 +1,8 @@
 package wif
 
 import (
+	"crypto/sha512"
+
 	btcec "github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
@@ -35,6 +37,20 @@ func (p *PrivateKey) FromByte(raw []byte) (err error) {
 	return
 }
 
+// GeneratePrivateKey creates a new private key from a cryptographically
+// secure random source.
+func GeneratePrivateKey() (*PrivateKey, error) {
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate private key")
+	}
+	p := &PrivateKey{}
+	if err := p.FromByte(privKey.Serialize()); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
 func (p *PrivateKey) ToByte() []byte {
 	return p.Raw.PrivKey.Serialize()
 }
@@ -50,3 +66,38 @@ func (p *PrivateKey) ToPubKeyStr() string {
 	}
 	return publicKey.ToStr()
 }
+
+// ToPublicKey returns the public key of the private key.
+func (p *PrivateKey) ToPublicKey() *PublicKey {
+	return &PublicKey{Raw: p.Raw.PrivKey.PubKey()}
+}
+
+// SharedSecret returns the ECDH shared secret with the given public key,
+// hashed with SHA-512 as in steem-js get_shared_secret.
+func (p *PrivateKey) SharedSecret(pub *PublicKey) []byte {
+	x := btcec.GenerateSharedSecret(p.Raw.PrivKey, pub.Raw)
+	secret := sha512.Sum512(x)
+	return secret[:]
+}
+
+// Child derives a child private key as steem-js does:
+// d + sha256(compressed public key || offset) mod n.
+func (p *PrivateKey) Child(offset []byte) (*PrivateKey, error) {
+	c, err := childTweak(p.ToPublicKey().ToByte(), offset)
+	if err != nil {
+		return nil, err
+	}
+
+	d := p.Raw.PrivKey.Key
+	d.Add(c)
+	if d.IsZero() {
+		return nil, errors.New("child offset derived to an empty key")
+	}
+
+	raw := d.Bytes()
+	child := &PrivateKey{}
+	if err := child.FromByte(raw[:]); err != nil {
+		return nil, err
+	}
+	return child, nil
+}
@@ -92,3 +92,46 @@ func TestToPubKeyStr(t *testing.T) {
 		}
 	}
 }
+
+func TestGeneratePrivateKey(t *testing.T) {
+	p1, err := GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2, err := GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p1.ToWif() == p2.ToWif() {
+		t.Error("expected two generated keys to differ")
+	}
+
+	// The generated key must round-trip through WIF.
+	p := &PrivateKey{}
+	if err := p.FromWif(p1.ToWif()); err != nil {
+		t.Error(err)
+	}
+	if got := p.ToPubKeyStr(); got != p1.ToPubKeyStr() {
+		t.Errorf("expected %v, got %v", p1.ToPubKeyStr(), got)
+	}
+}
+
+func TestSharedSecret(t *testing.T) {
+	alice, err := GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s1 := alice.SharedSecret(bob.ToPublicKey())
+	s2 := bob.SharedSecret(alice.ToPublicKey())
+	if len(s1) != 64 {
+		t.Errorf("expected 64 bytes, got %d", len(s1))
+	}
+	if string(s1) != string(s2) {
+		t.Error("expected the shared secret to be symmetric")
+	}
+}
@@ -2,7 +2,10 @@ package wif
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"hash"
+	"strings"
 
 	secp256k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/steemit/steemutil/consts"
@@ -18,6 +21,19 @@ import (
 
 type PublicKey struct {
 	Raw *secp256k1.PublicKey
+
+	// Prefix is the address prefix used by ToStr. It is set by FromStr and
+	// defaults to consts.ADDRESS_PREFIX.
+	Prefix string
+}
+
+// ParsePublicKey parses a public key string of the given chain.
+func ParsePublicKey(pubKey string, cfg *consts.ChainConfig) (*PublicKey, error) {
+	p := &PublicKey{}
+	if err := p.FromStrWithConfig(pubKey, cfg); err != nil {
+		return nil, err
+	}
+	return p, nil
 }
 
 // Get a raw public key from []byte.
@@ -30,17 +46,56 @@ func (p *PublicKey) FromByte(raw []byte) (err error) {
 	return nil
 }
 
-// Get a raw public key from Public String (STM...).
+// GraphenePrefixes lists address prefixes of other Graphene chains that
+// FromStrAnyPrefix accepts in addition to the prefixes of consts.KnownChains.
+var GraphenePrefixes = []string{"GLS", "BTS", "GPH", "EOS", "WLS", "SCR"}
+
+// Get a raw public key from Public String (STM...). Only the address prefix
+// consts.ADDRESS_PREFIX is accepted; use FromStrWithConfig for other chains.
 func (p *PublicKey) FromStr(pubKey string) (err error) {
+	return p.FromStrWithPrefix(strings.TrimSpace(pubKey), consts.ADDRESS_PREFIX)
+}
+
+// FromStrWithConfig parses a public key string that must use the address
+// prefix of the given chain.
+func (p *PublicKey) FromStrWithConfig(pubKey string, cfg *consts.ChainConfig) (err error) {
+	return p.FromStrWithPrefix(strings.TrimSpace(pubKey), cfg.AddressPrefix)
+}
+
+// FromStrAnyPrefix parses a public key of unknown origin. The address prefix
+// of any chain in consts.KnownChains or GraphenePrefixes is accepted, as well
+// as the compressed or uncompressed key encoded as hex.
+func (p *PublicKey) FromStrAnyPrefix(pubKey string) (err error) {
+	pubKey = strings.TrimSpace(pubKey)
+	if raw, ok := decodeHexKey(pubKey); ok {
+		return p.FromByte(raw)
+	}
+
+	err = errors.New("public key has an error prefix")
+	for _, prefix := range knownPrefixes() {
+		if !strings.HasPrefix(pubKey, prefix) {
+			continue
+		}
+		if err = p.FromStrWithPrefix(pubKey, prefix); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// FromStrWithPrefix parses a public key string that must use the given
+// address prefix. The key may be compressed or uncompressed.
+func (p *PublicKey) FromStrWithPrefix(pubKey, prefix string) (err error) {
 	// check prefix
-	prefixLen := len(consts.ADDRESS_PREFIX)
-	prefix := pubKey[0:prefixLen]
-	if prefix != consts.ADDRESS_PREFIX {
+	if !strings.HasPrefix(pubKey, prefix) {
 		return errors.New("public key has an error prefix")
 	}
 	// get pub key without prefix
-	pubKeyWithoutPrefix := pubKey[prefixLen:]
+	pubKeyWithoutPrefix := pubKey[len(prefix):]
 	pubKeyByte := base58.Decode(pubKeyWithoutPrefix)
+	if len(pubKeyByte) != compressedKeyLength+4 && len(pubKeyByte) != uncompressedKeyLength+4 {
+		return errors.Errorf("public key has an invalid length: %d bytes", len(pubKeyByte))
+	}
 
 	// check checksum
 	pubKeyOri := pubKeyByte[0 : len(pubKeyByte)-4]
@@ -51,8 +106,36 @@ func (p *PublicKey) FromStr(pubKey string) (err error) {
 	}
 
 	// save
-	err = p.FromByte(pubKeyOri)
-	return err
+	if err := p.FromByte(pubKeyOri); err != nil {
+		return err
+	}
+	p.Prefix = prefix
+	return nil
+}
+
+const (
+	compressedKeyLength   = 33
+	uncompressedKeyLength = 65
+)
+
+// decodeHexKey decodes a compressed or uncompressed public key in hex.
+func decodeHexKey(pubKey string) ([]byte, bool) {
+	if len(pubKey) != 2*compressedKeyLength && len(pubKey) != 2*uncompressedKeyLength {
+		return nil, false
+	}
+	raw, err := hex.DecodeString(pubKey)
+	if err != nil {
+		return nil, false
+	}
+	return raw, true
+}
+
+func knownPrefixes() []string {
+	prefixes := make([]string, 0, len(consts.KnownChains)+len(GraphenePrefixes))
+	for _, cfg := range consts.KnownChains {
+		prefixes = append(prefixes, cfg.AddressPrefix)
+	}
+	return append(prefixes, GraphenePrefixes...)
 }
 
 func (p *PublicKey) FromWif(wif string) (err error) {
@@ -66,16 +149,67 @@ func (p *PublicKey) FromWif(wif string) (err error) {
 }
 
 func (p *PublicKey) ToStr() string {
+	if p.Prefix == "" {
+		return p.ToStrWithPrefix(consts.ADDRESS_PREFIX)
+	}
+	return p.ToStrWithPrefix(p.Prefix)
+}
+
+// ToStrWithPrefix returns the public key string with the given address prefix.
+func (p *PublicKey) ToStrWithPrefix(prefix string) string {
 	checkSum := calcHash(p.Raw.SerializeCompressed(), ripemd160.New())
 	pubByte := append(p.Raw.SerializeCompressed(), checkSum[0:4]...)
 	pubStr := base58.Encode(pubByte)
-	return consts.ADDRESS_PREFIX + pubStr
+	return prefix + pubStr
 }
 
 func (p *PublicKey) ToByte() []byte {
 	return p.Raw.SerializeCompressed()
 }
 
+// ToHex returns the compressed public key encoded as hex.
+func (p *PublicKey) ToHex() string {
+	return hex.EncodeToString(p.ToByte())
+}
+
+// Child derives a child public key as steem-js does:
+// Q + sha256(compressed Q || offset) * G. It matches PrivateKey.Child for the
+// same offset, so children can be derived without the private key.
+func (p *PublicKey) Child(offset []byte) (*PublicKey, error) {
+	c, err := childTweak(p.ToByte(), offset)
+	if err != nil {
+		return nil, err
+	}
+
+	var point, tweak, child secp256k1.JacobianPoint
+	p.Raw.AsJacobian(&point)
+	secp256k1.ScalarBaseMultNonConst(c, &tweak)
+	secp256k1.AddNonConst(&point, &tweak, &child)
+	if (child.X.IsZero() && child.Y.IsZero()) || child.Z.IsZero() {
+		return nil, errors.New("child offset derived to an empty key")
+	}
+	child.ToAffine()
+
+	return &PublicKey{
+		Raw:    secp256k1.NewPublicKey(&child.X, &child.Y),
+		Prefix: p.Prefix,
+	}, nil
+}
+
+// childTweak returns sha256(pubKey || offset) as a scalar.
+func childTweak(pubKey, offset []byte) (*secp256k1.ModNScalar, error) {
+	if len(offset) != 32 {
+		return nil, errors.Errorf("child offset must be 32 bytes, got %d", len(offset))
+	}
+	hash := sha256.Sum256(append(append([]byte{}, pubKey...), offset...))
+
+	var c secp256k1.ModNScalar
+	if overflow := c.SetByteSlice(hash[:]); overflow {
+		return nil, errors.New("child offset went out of bounds")
+	}
+	return &c, nil
+}
+
 func calcHash(buf []byte, hasher hash.Hash) []byte {
 	_, _ = hasher.Write(buf)
 	return hasher.Sum(nil)
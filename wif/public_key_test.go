@@ -1,7 +1,16 @@
 package wif
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/steemit/steemutil/consts"
+
+	//nolint:staticcheck // RIPEMD-160 is required by Steem protocol for public key checksum
+	"golang.org/x/crypto/ripemd160"
 )
 
 func TestFromStrToStr(t *testing.T) {
@@ -37,3 +46,129 @@ func TestPublicKeyFromWif(t *testing.T) {
 		}
 	}
 }
+
+func TestTestnetPublicKey(t *testing.T) {
+	for _, d := range data {
+		p := &PublicKey{}
+		if err := p.FromStr(d.PublicKey); err != nil {
+			t.Fatal(err)
+		}
+		tst := p.ToStrWithPrefix(consts.SteemTestnet.AddressPrefix)
+
+		parsed, err := ParsePublicKey(tst, consts.SteemTestnet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := parsed.ToStr(); got != tst {
+			t.Errorf("expected %v, got %v", tst, got)
+		}
+		if !bytes.Equal(parsed.ToByte(), p.ToByte()) {
+			t.Errorf("expected the same key for %v and %v", d.PublicKey, tst)
+		}
+
+		// FromStr only accepts the default prefix.
+		if err := (&PublicKey{}).FromStr(tst); err == nil {
+			t.Errorf("expected an error for %v", tst)
+		}
+		if err := (&PublicKey{}).FromStrWithConfig(tst, consts.SteemTestnet); err != nil {
+			t.Error(err)
+		}
+		if err := (&PublicKey{}).FromStrAnyPrefix(tst); err != nil {
+			t.Error(err)
+		}
+		if _, err := ParsePublicKey(tst, consts.SteemMainnet); err == nil {
+			t.Errorf("expected an error for %v on mainnet", tst)
+		}
+	}
+}
+
+func TestPublicKeyFromStrInvalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"STM",
+		"STM1",
+		"STMinvalid",
+		"TST0OIl",
+		"XYZ6Z5Q2zF5X9dvn9Mk9Qq9Sui9WWnoBX1mgD6dHtHwvnggvpQfmi",
+		"02abcd",
+	}
+	for _, input := range inputs {
+		p := &PublicKey{}
+		if err := p.FromStr(input); err == nil {
+			t.Errorf("expected an error for %q", input)
+		}
+		if err := p.FromStrAnyPrefix(input); err == nil {
+			t.Errorf("expected an error for %q with any prefix", input)
+		}
+	}
+}
+
+func TestPublicKeyFromStrFormats(t *testing.T) {
+	for _, d := range data {
+		p := &PublicKey{}
+		if err := p.FromStr(d.PublicKey); err != nil {
+			t.Fatal(err)
+		}
+
+		uncompressed := p.Raw.SerializeUncompressed()
+		checkSum := calcHash(uncompressed, ripemd160.New())
+		inputs := map[string]string{
+			"uncompressed":     "STM" + base58.Encode(append(uncompressed, checkSum[0:4]...)),
+			"hex":              p.ToHex(),
+			"uncompressed hex": hex.EncodeToString(uncompressed),
+			"graphene prefix":  p.ToStrWithPrefix("GLS"),
+		}
+		for name, input := range inputs {
+			parsed := &PublicKey{}
+			if err := parsed.FromStrAnyPrefix(input); err != nil {
+				t.Errorf("%s: %v", name, err)
+				continue
+			}
+			if got := parsed.ToStrWithPrefix("STM"); got != d.PublicKey {
+				t.Errorf("%s: expected %v, got %v", name, d.PublicKey, got)
+			}
+
+			// Only the uncompressed key has the STM prefix FromStr requires.
+			err := (&PublicKey{}).FromStr(input)
+			if strict := name == "uncompressed"; (err == nil) != strict {
+				t.Errorf("%s: unexpected FromStr result %v", name, err)
+			}
+		}
+	}
+}
+
+func TestChildKeys(t *testing.T) {
+	offset := sha256.Sum256([]byte("offset"))
+	for _, d := range data {
+		priv := &PrivateKey{}
+		if err := priv.FromWif(d.WIF); err != nil {
+			t.Fatal(err)
+		}
+		pub := priv.ToPublicKey()
+
+		privChild, err := priv.Child(offset[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubChild, err := pub.Child(offset[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := pubChild.ToStr()
+		if got := privChild.ToPubKeyStr(); got != expected {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+		if expected == pub.ToStr() {
+			t.Errorf("expected the child key to differ from %v", pub.ToStr())
+		}
+	}
+
+	priv := &PrivateKey{}
+	if err := priv.FromWif(data[0].WIF); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := priv.Child([]byte("short")); err == nil {
+		t.Error("expected an error for a short offset")
+	}
+}